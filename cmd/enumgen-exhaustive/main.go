@@ -0,0 +1,25 @@
+// Command enumgen-exhaustive reports switch statements over enumgen enums that
+// miss members and have no default case.
+//
+// It can be run on its own or as a vet tool:
+//
+//	go vet -vettool=$(which enumgen-exhaustive) ./...
+//
+// Built with -buildmode=plugin, it also serves as a golangci-lint plugin.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/kkumar-gcc/enumgen/src/analysis/exhaustive"
+)
+
+// New is the entry point looked up by golangci-lint when loading the plugin.
+func New(conf any) ([]*analysis.Analyzer, error) {
+	return exhaustive.New(conf)
+}
+
+func main() {
+	singlechecker.Main(exhaustive.Analyzer)
+}
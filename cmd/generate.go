@@ -66,6 +66,14 @@ It reads the source files, parses them, and generates the corresponding enum def
 			fmt.Println(compilerCtx.Validations.FormatWarnings())
 		}
 
+		// Diagnostics from the resolving stages do not abort the pipeline on their own.
+		if err == nil && len(compilerCtx.Errors) > 0 {
+			fmt.Println(compilerCtx.Errors.Format())
+			if compilerCtx.Errors.HasErrors() {
+				return nil
+			}
+		}
+
 		if err != nil {
 			if len(compilerCtx.Errors) > 0 {
 				fmt.Println(compilerCtx.Errors.Format())
@@ -79,6 +87,9 @@ It reads the source files, parses them, and generates the corresponding enum def
 		outputDir = compilerCtx.OutputDir
 		for _, file := range compilerCtx.OutputFiles {
 			file.Path = filepath.Join(outputDir, file.Path)
+			if err := os.MkdirAll(filepath.Dir(file.Path), 0755); err != nil {
+				return fmt.Errorf("failed to create directory for %s: %w", file.Path, err)
+			}
 			if err := os.WriteFile(file.Path, file.Body, 0644); err != nil {
 				return fmt.Errorf("failed to write file %s: %w", file.Path, err)
 			}
@@ -3,3 +3,9 @@ module github.com/kkumar-gcc/enumgen
 go 1.24.0
 
 require github.com/urfave/cli/v3 v3.3.3
+
+require (
+	golang.org/x/mod v0.23.0 // indirect
+	golang.org/x/sync v0.11.0 // indirect
+	golang.org/x/tools v0.30.0
+)
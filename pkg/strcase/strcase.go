@@ -0,0 +1,89 @@
+package strcase
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ToPascal converts an identifier in SCREAMING_SNAKE, snake, kebab or camel
+// case to PascalCase.
+//
+//	ToPascal("DARK_BLUE") // "DarkBlue"
+//	ToPascal("notFound")  // "NotFound"
+//	ToPascal("HTTPError") // "HttpError"
+func ToPascal(s string) string {
+	var sb strings.Builder
+	for _, word := range Words(s) {
+		runes := []rune(strings.ToLower(word))
+		runes[0] = unicode.ToUpper(runes[0])
+		sb.WriteString(string(runes))
+	}
+	return sb.String()
+}
+
+// ToCamel converts an identifier to camelCase, the PascalCase form with a
+// lower case first letter.
+//
+//	ToCamel("RETRY_AFTER") // "retryAfter"
+//	ToCamel("Code")        // "code"
+func ToCamel(s string) string {
+	runes := []rune(ToPascal(s))
+	if len(runes) > 0 {
+		runes[0] = unicode.ToLower(runes[0])
+	}
+	return string(runes)
+}
+
+// ToSnake converts an identifier to snake_case.
+//
+//	ToSnake("RETRY_AFTER") // "retry_after"
+//	ToSnake("HTTPStatus")  // "http_status"
+func ToSnake(s string) string {
+	words := Words(s)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return strings.Join(words, "_")
+}
+
+// Words splits an identifier into its words. Underscores, hyphens and spaces
+// separate words, as do lower-to-upper case transitions and the last capital
+// of an acronym followed by a lower case letter.
+func Words(s string) []string {
+	var words []string
+	runes := []rune(s)
+	start := -1
+
+	flush := func(end int) {
+		if start >= 0 && end > start {
+			words = append(words, string(runes[start:end]))
+		}
+		start = -1
+	}
+
+	for i, r := range runes {
+		if r == '_' || r == '-' || unicode.IsSpace(r) {
+			flush(i)
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+
+		prev := runes[i-1]
+		switch {
+		case unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)):
+			// fooBar, foo2Bar
+			flush(i)
+			start = i
+		case unicode.IsUpper(r) && unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+			// HTTPError
+			flush(i)
+			start = i
+		}
+	}
+	flush(len(runes))
+
+	return words
+}
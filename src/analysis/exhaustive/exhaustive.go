@@ -0,0 +1,226 @@
+// Package exhaustive defines an analyzer that reports switch statements over
+// enumgen enums which neither handle every member nor have a default case.
+//
+// Enum types and their members are recognized by the directives the Go
+// generator emits (see golang.EnumDirective and golang.MembersDirective), so
+// only generated enums are checked.
+package exhaustive
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/kkumar-gcc/enumgen/src/codegen/golang"
+)
+
+const doc = `check that switch statements over enumgen enums are exhaustive
+
+A switch statement whose tag is an enum generated by enumgen must either
+list every member of the enum or have a default case.`
+
+var Analyzer = &analysis.Analyzer{
+	Name:      "exhaustive",
+	Doc:       doc,
+	Run:       run,
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	FactTypes: []analysis.Fact{new(enumFact)},
+}
+
+// New returns the analyzers of this package. Its signature matches what
+// golangci-lint expects from a linter plugin.
+func New(conf any) ([]*analysis.Analyzer, error) {
+	return []*analysis.Analyzer{Analyzer}, nil
+}
+
+// enumFact is attached to the type name of every generated enum, so that
+// switch statements in importing packages can be checked too.
+type enumFact struct {
+	Members []string
+	Aliases map[string]string // member aliases, such as FAILURE = ERROR, by the member they name
+}
+
+func (*enumFact) AFact() {}
+
+func (f *enumFact) String() string {
+	return "enum(" + strings.Join(f.Members, ", ") + ")"
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	exportEnums(pass)
+
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	insp.Preorder([]ast.Node{(*ast.SwitchStmt)(nil)}, func(n ast.Node) {
+		checkSwitch(pass, n.(*ast.SwitchStmt))
+	})
+
+	return nil, nil
+}
+
+// exportEnums finds the enum types declared in the package and exports their
+// members and member aliases as facts.
+func exportEnums(pass *analysis.Pass) {
+	var enums []*types.TypeName
+	members := make(map[*types.TypeName][]string)
+	owners := make(map[types.Object]*types.TypeName)
+	var aliases []*ast.ValueSpec
+
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+
+			switch {
+			case hasDirective(gen.Doc, golang.EnumDirective):
+				for _, spec := range gen.Specs {
+					if ts, ok := spec.(*ast.TypeSpec); ok {
+						if tn, ok := pass.TypesInfo.Defs[ts.Name].(*types.TypeName); ok {
+							enums = append(enums, tn)
+						}
+					}
+				}
+			case hasDirective(gen.Doc, golang.MembersDirective):
+				for _, spec := range gen.Specs {
+					vs, ok := spec.(*ast.ValueSpec)
+					if !ok {
+						continue
+					}
+					for _, name := range vs.Names {
+						obj := pass.TypesInfo.Defs[name]
+						if obj == nil {
+							continue
+						}
+						if named, ok := types.Unalias(obj.Type()).(*types.Named); ok {
+							members[named.Obj()] = append(members[named.Obj()], obj.Name())
+							owners[obj] = named.Obj()
+						}
+					}
+				}
+			default:
+				for _, spec := range gen.Specs {
+					if vs, ok := spec.(*ast.ValueSpec); ok {
+						aliases = append(aliases, vs)
+					}
+				}
+			}
+		}
+	}
+
+	// A member alias is declared apart from the members and assigned one of
+	// them. It is not a member of its own, but a case naming it covers the
+	// member.
+	facts := make(map[*types.TypeName]*enumFact, len(enums))
+	for _, tn := range enums {
+		facts[tn] = &enumFact{Members: members[tn]}
+	}
+	for _, vs := range aliases {
+		for i, name := range vs.Names {
+			if i >= len(vs.Values) {
+				break
+			}
+			ident, ok := ast.Unparen(vs.Values[i]).(*ast.Ident)
+			if !ok {
+				continue
+			}
+			target := pass.TypesInfo.Uses[ident]
+			fact := facts[owners[target]]
+			if fact == nil || pass.TypesInfo.Defs[name] == nil {
+				continue
+			}
+			if fact.Aliases == nil {
+				fact.Aliases = make(map[string]string)
+			}
+			fact.Aliases[name.Name] = target.Name()
+		}
+	}
+
+	for _, tn := range enums {
+		pass.ExportObjectFact(tn, facts[tn])
+	}
+}
+
+func checkSwitch(pass *analysis.Pass, sw *ast.SwitchStmt) {
+	if sw.Tag == nil {
+		return
+	}
+	named, ok := types.Unalias(pass.TypesInfo.TypeOf(sw.Tag)).(*types.Named)
+	if !ok {
+		return
+	}
+	enum := named.Obj()
+
+	var fact enumFact
+	if !pass.ImportObjectFact(enum, &fact) {
+		return
+	}
+
+	covered := make(map[string]bool)
+	values := make(map[string]bool)
+	for _, stmt := range sw.Body.List {
+		clause := stmt.(*ast.CaseClause)
+		if clause.List == nil {
+			return // default case
+		}
+		for _, expr := range clause.List {
+			if obj := objectOf(pass, expr); obj != nil && obj.Pkg() == enum.Pkg() {
+				covered[obj.Name()] = true
+				if member, ok := fact.Aliases[obj.Name()]; ok {
+					covered[member] = true
+				}
+			}
+			if tv, ok := pass.TypesInfo.Types[expr]; ok && tv.Value != nil {
+				values[tv.Value.ExactString()] = true
+			}
+		}
+	}
+
+	// Constant members sharing a value are a single case, which is covered
+	// by any case with that value.
+	var missing []string
+	reported := make(map[string]bool)
+	for _, member := range fact.Members {
+		if covered[member] {
+			continue
+		}
+		if c, ok := enum.Pkg().Scope().Lookup(member).(*types.Const); ok {
+			value := c.Val().ExactString()
+			if values[value] || reported[value] {
+				continue
+			}
+			reported[value] = true
+		}
+		missing = append(missing, member)
+	}
+
+	if len(missing) > 0 {
+		pass.Reportf(sw.Pos(), "missing cases in switch of type %s: %s",
+			types.TypeString(named, types.RelativeTo(pass.Pkg)), strings.Join(missing, ", "))
+	}
+}
+
+// objectOf returns the object an identifier or qualified identifier refers to.
+func objectOf(pass *analysis.Pass, expr ast.Expr) types.Object {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		return pass.TypesInfo.Uses[e]
+	case *ast.SelectorExpr:
+		return pass.TypesInfo.Uses[e.Sel]
+	}
+	return nil
+}
+
+func hasDirective(doc *ast.CommentGroup, directive string) bool {
+	if doc == nil {
+		return false
+	}
+	return slices.ContainsFunc(doc.List, func(c *ast.Comment) bool {
+		return c.Text == directive
+	})
+}
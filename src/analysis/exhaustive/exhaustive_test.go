@@ -0,0 +1,119 @@
+package exhaustive_test
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/kkumar-gcc/enumgen/src/analysis/exhaustive"
+	"github.com/kkumar-gcc/enumgen/src/codegen"
+	"github.com/kkumar-gcc/enumgen/src/compiler"
+)
+
+// enumSource labels a member, so the switch of the generated Label method is
+// checked as well, and gives RED an alias, which a switch may use in its place.
+const enumSource = `
+enum Color [string]:
+    @label("Red")
+    RED = "red",
+    GREEN = "green",
+    BLUE = "blue",
+    CRIMSON = RED;
+`
+
+const usage = `package app
+
+import "colors"
+
+func name(c colors.Color) string {
+	switch c { // want "missing cases in switch of type colors.Color: BLUE"
+	case colors.RED:
+		return "red"
+	case colors.GREEN:
+		return "green"
+	}
+
+	switch c {
+	case colors.RED, colors.GREEN, colors.BLUE:
+	}
+
+	switch c {
+	case colors.RED:
+	default:
+	}
+
+	switch c {
+	case colors.CRIMSON, colors.GREEN, colors.BLUE:
+	}
+
+	switch c { // want "missing cases in switch of type colors.Color: RED"
+	case colors.GREEN, colors.BLUE:
+	}
+	return ""
+}
+`
+
+const local = `package colors
+
+func isWarm(c Color) bool {
+	switch c { // want "missing cases in switch of type Color: GREEN, BLUE"
+	case RED:
+		return true
+	}
+	return false
+}
+`
+
+var typeDecl = regexp.MustCompile(`(?m)^type Color .*$`)
+
+// writeGenerated compiles the enums with the Go generator into dir, so the
+// analyzer is always tested against the current generator output.
+func writeGenerated(t *testing.T, dir string, options map[string]string) {
+	t.Helper()
+	codegen.Init()
+
+	path := filepath.Join(t.TempDir(), "enums.edl")
+	if err := os.WriteFile(path, []byte(enumSource), 0644); err != nil {
+		t.Fatalf("failed to write source: %v", err)
+	}
+
+	ctx, err := compiler.CompileFile(path, "", "go", false, options)
+	if err != nil {
+		t.Fatalf("compilation failed: %v", err)
+	}
+	for _, file := range ctx.OutputFiles {
+		// The exported fact is expected on the line declaring the enum type.
+		body := typeDecl.ReplaceAll(file.Body, []byte("$0 // want Color:`enum\\(RED, GREEN, BLUE\\)`"))
+		if err := os.WriteFile(filepath.Join(dir, file.Path), body, 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", file.Path, err)
+		}
+	}
+}
+
+func TestAnalyzer(t *testing.T) {
+	for _, style := range []string{"standard", "iota", "const"} {
+		t.Run(style, func(t *testing.T) {
+			root := t.TempDir()
+			colors := filepath.Join(root, "src", "colors")
+			app := filepath.Join(root, "src", "app")
+			for _, dir := range []string{colors, app} {
+				if err := os.MkdirAll(dir, 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			writeGenerated(t, colors, map[string]string{"package": "colors", "enum_style": style})
+			if err := os.WriteFile(filepath.Join(colors, "local.go"), []byte(local), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(app, "app.go"), []byte(usage), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			analysistest.Run(t, root, exhaustive.Analyzer, "colors", "app")
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package ast
 
-import "github.com/kkumar-gcc/enumgen/src/token"
+import (
+	"strings"
+
+	"github.com/kkumar-gcc/enumgen/src/token"
+)
 
 type Node interface {
 	Pos() token.Position
@@ -54,6 +58,30 @@ func (r *CommentGroup) Add(c *Comment) {
 	r.List = append(r.List, c)
 }
 
+// Text returns the text of the comment group with the comment markers and the
+// first space after them removed. Leading and trailing blank lines are dropped.
+func (r *CommentGroup) Text() string {
+	if r == nil {
+		return ""
+	}
+
+	lines := make([]string, 0, len(r.List))
+	for _, c := range r.List {
+		text := strings.TrimPrefix(c.Text, "//")
+		text = strings.TrimPrefix(text, " ")
+		lines = append(lines, strings.TrimRight(text, " \t\r"))
+	}
+
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
 func (r *CommentGroup) String() string {
 	var out string
 	for _, c := range r.List {
@@ -74,9 +102,8 @@ type (
 		Value    string
 	}
 
-	// UnaryExpr represents a unary expression like `-x` or `!y`.
-	// TODO: It is still not clear how to handle unary expressions in enum definitions.
-	// For now, we will treat them as invalid, but we might need to revisit this.
+	// UnaryExpr represents a unary expression like `-x`.
+	// Only negation of numeric literals is currently produced by the parser.
 	UnaryExpr struct {
 		OpPos token.Position
 		Op    token.Token
@@ -89,36 +116,90 @@ type (
 		Value Expr
 	}
 
+	// RefExpr references an enum member, either of the enclosing enum
+	// (`PRIMARY`) or qualified by the enum declaring it (`Status.ERROR`).
+	RefExpr struct {
+		Enum   *Ident // qualifying enum, if any
+		DotPos token.Position
+		Name   Ident
+
+		// Target and TargetEnum are the member the reference resolves to and
+		// its enum. They are set by the reference resolver.
+		Target     *MemberDefinition
+		TargetEnum *EnumDefinition
+	}
+
 	TypeRef struct {
 		Package *Ident         // Optional: package.Type
 		DotPos  token.Position // Dot position if Package != nil
 		Name    Ident
 	}
 
+	// Field is a named field of a tuple enum, such as `code int unique`.
+	Field struct {
+		Name      Ident
+		Type      *TypeRef
+		UniquePos token.Position // position of the 'unique' modifier, if any
+	}
+
 	TypeSpec struct {
 		LbrackPos token.Position
 		Doc       *CommentGroup
 		Types     []*TypeRef
+		Fields    []*Field // named fields of a tuple enum; Types is empty if set
 		Commas    []token.Position
 		RbrackPos token.Position
 	}
 
+	// TupleExpr represents the values of a tuple enum member, such as `(200, "OK")`.
+	TupleExpr struct {
+		Lparen token.Position
+		Elts   []Expr
+		Rparen token.Position
+	}
+
+	// Annotation represents an annotation such as `@label("Dark Red")` on an enum or member.
+	Annotation struct {
+		At     token.Position
+		Name   Ident
+		Lparen token.Position // position of '(', if the annotation has arguments
+		Args   []Expr
+		Rparen token.Position
+	}
+
 	MemberDefinition struct {
-		Doc       *CommentGroup
-		Name      Ident
-		AssignPos token.Position
-		Value     Expr
-		TermPos   token.Position
+		Doc         *CommentGroup
+		Annotations []*Annotation
+		Name        Ident
+		AssignPos   token.Position
+		Value       Expr
+		AliasPos    token.Position // position of the 'alias' keyword, if any
+		Aliases     []*BasicLit    // alternative keys accepted when parsing
+		TermPos     token.Position
+		Comment     *CommentGroup // trailing comment on the member's line
 	}
 
 	// --- Declarations ---
 
 	EnumDefinition struct {
-		Doc      *CommentGroup
-		EnumPos  token.Position
-		Name     Ident
-		TypeSpec *TypeSpec
-		Members  []*MemberDefinition
+		Doc         *CommentGroup
+		Annotations []*Annotation
+		EnumPos     token.Position
+		Name        Ident
+		TypeSpec    *TypeSpec
+		FlagsPos    token.Position // position of the 'flags' modifier, if any
+		Comment     *CommentGroup  // trailing comment on the declaration line
+		Members     []*MemberDefinition
+	}
+
+	// OptionDecl represents a file-level option such as `option default_type = int;`.
+	OptionDecl struct {
+		Doc       *CommentGroup
+		OptionPos token.Position
+		Name      Ident
+		AssignPos token.Position
+		Value     Expr
+		TermPos   token.Position
 	}
 
 	BadDecl struct {
@@ -168,6 +249,111 @@ func (r *KeyValueExpr) String() string {
 }
 func (r *KeyValueExpr) exprNode() {}
 
+func (r *RefExpr) Pos() token.Position {
+	if r.Enum != nil {
+		return r.Enum.Pos()
+	}
+	return r.Name.Pos()
+}
+func (r *RefExpr) End() token.Position { return r.Name.End() }
+func (r *RefExpr) String() string {
+	if r.Enum != nil {
+		return r.Enum.Name + "." + r.Name.Name
+	}
+	return r.Name.Name
+}
+func (r *RefExpr) exprNode() {}
+
+// Final follows the reference, and any references the referenced member is
+// assigned, to the first member whose value is not a reference. It returns nil
+// if a reference on the way is unresolved or the references form a cycle.
+func (r *RefExpr) Final() *MemberDefinition {
+	seen := make(map[*MemberDefinition]bool)
+	for ref := r; ref.Target != nil && !seen[ref.Target]; {
+		seen[ref.Target] = true
+		next, ok := ref.Target.Value.(*RefExpr)
+		if !ok {
+			return ref.Target
+		}
+		ref = next
+	}
+	return nil
+}
+
+func (r *Annotation) Pos() token.Position { return r.At }
+func (r *Annotation) End() token.Position {
+	if r.Rparen.IsValid() {
+		return token.Position{Line: r.Rparen.Line, Column: r.Rparen.Column + 1}
+	}
+	return r.Name.End()
+}
+func (r *Annotation) String() string {
+	out := "@" + r.Name.String()
+	if r.Lparen.IsValid() {
+		out += "("
+		for i, arg := range r.Args {
+			if i > 0 {
+				out += ", "
+			}
+			out += arg.String()
+		}
+		out += ")"
+	}
+	return out
+}
+
+// findAnnotation returns the first annotation with the given name, or nil.
+func findAnnotation(annotations []*Annotation, name string) *Annotation {
+	for _, a := range annotations {
+		if a.Name.Name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+func annotationsString(annotations []*Annotation) string {
+	var out string
+	for _, a := range annotations {
+		out += a.String() + " "
+	}
+	return out
+}
+
+func (r *TupleExpr) Pos() token.Position { return r.Lparen }
+func (r *TupleExpr) End() token.Position {
+	return token.Position{Line: r.Rparen.Line, Column: r.Rparen.Column + 1}
+}
+func (r *TupleExpr) String() string {
+	out := "("
+	for i, elt := range r.Elts {
+		if i > 0 {
+			out += ", "
+		}
+		out += elt.String()
+	}
+	return out + ")"
+}
+func (r *TupleExpr) exprNode() {}
+
+func (r *Field) Pos() token.Position { return r.Name.Pos() }
+func (r *Field) End() token.Position {
+	if r.UniquePos.IsValid() {
+		return token.Position{Line: r.UniquePos.Line, Column: r.UniquePos.Column + len("unique")}
+	}
+	return r.Type.End()
+}
+func (r *Field) String() string {
+	out := r.Name.String() + " " + r.Type.String()
+	if r.IsUnique() {
+		out += " unique"
+	}
+	return out
+}
+
+// IsUnique reports whether the field was declared with the 'unique' modifier.
+func (r *Field) IsUnique() bool { return r.UniquePos.IsValid() }
+
 func (r *TypeRef) Pos() token.Position {
 	if r.Package != nil {
 		return r.Package.Pos()
@@ -193,11 +379,37 @@ func (r *TypeSpec) String() string {
 		}
 		out += t.String()
 	}
+	for i, f := range r.Fields {
+		if i > 0 {
+			out += ", "
+		}
+		out += f.String()
+	}
 	return out + "]"
 }
 
+// IsTuple reports whether the type specification declares named tuple fields.
+func (r *TypeSpec) IsTuple() bool { return len(r.Fields) > 0 }
+
+// DocText returns the member's documentation: its lead comment when present,
+// otherwise its trailing line comment.
+func (r *MemberDefinition) DocText() string {
+	return docText(r.Doc, r.Comment)
+}
+
+// Annotation returns the member's first annotation with the given name, or nil.
+func (r *MemberDefinition) Annotation(name string) *Annotation {
+	return findAnnotation(r.Annotations, name)
+}
+
+// IsDefault reports whether the member was marked with '@default'.
+func (r *MemberDefinition) IsDefault() bool { return r.Annotation("default") != nil }
+
 func (r *MemberDefinition) Pos() token.Position { return r.Name.Pos() }
 func (r *MemberDefinition) End() token.Position {
+	if n := len(r.Aliases); n > 0 {
+		return r.Aliases[n-1].End()
+	}
 	if r.Value != nil {
 		return r.Value.End()
 	}
@@ -208,11 +420,20 @@ func (r *MemberDefinition) String() string {
 	if r.Doc != nil {
 		out += r.Doc.String() + "\n"
 	}
+	out += annotationsString(r.Annotations)
 	if r.Value != nil {
 		out += r.Name.String() + " = " + r.Value.String()
 	} else {
 		out += r.Name.String()
 	}
+	for i, alias := range r.Aliases {
+		if i == 0 {
+			out += " alias "
+		} else {
+			out += ", "
+		}
+		out += alias.String()
+	}
 	if r.TermPos.IsValid() {
 		out += ";"
 	}
@@ -220,6 +441,31 @@ func (r *MemberDefinition) String() string {
 }
 
 func (r *EnumDefinition) Pos() token.Position { return r.EnumPos }
+
+// DocText returns the enum's documentation: its lead comment when present,
+// otherwise its trailing line comment.
+func (r *EnumDefinition) DocText() string {
+	return docText(r.Doc, r.Comment)
+}
+
+// Annotation returns the enum's first annotation with the given name, or nil.
+func (r *EnumDefinition) Annotation(name string) *Annotation {
+	return findAnnotation(r.Annotations, name)
+}
+
+// IsTuple reports whether the enum declares named fields, making its members tuples.
+func (r *EnumDefinition) IsTuple() bool { return r.TypeSpec != nil && r.TypeSpec.IsTuple() }
+
+// IsAlias reports whether member, a member of the enum, is assigned another
+// member of the same enum and thus is an alias of it, e.g. `ALIAS = PRIMARY`.
+func (r *EnumDefinition) IsAlias(member *MemberDefinition) bool {
+	ref, ok := member.Value.(*RefExpr)
+	return ok && (ref.Enum == nil || ref.Enum.Name == r.Name.Name)
+}
+
+// IsFlags reports whether the enum was declared with the 'flags' modifier.
+func (r *EnumDefinition) IsFlags() bool { return r.FlagsPos.IsValid() }
+
 func (r *EnumDefinition) End() token.Position {
 	if len(r.Members) > 0 {
 		return r.Members[len(r.Members)-1].End()
@@ -232,10 +478,14 @@ func (r *EnumDefinition) String() string {
 		out += r.Doc.String() + "\n"
 	}
 
-	out = "enum " + r.Name.String()
+	out += annotationsString(r.Annotations)
+	out += "enum " + r.Name.String()
 	if r.TypeSpec != nil {
 		out += " " + r.TypeSpec.String()
 	}
+	if r.IsFlags() {
+		out += " flags"
+	}
 	out += " {"
 	for _, m := range r.Members {
 		out += "\n  " + m.String()
@@ -245,6 +495,22 @@ func (r *EnumDefinition) String() string {
 }
 func (r *EnumDefinition) declNode() {}
 
+func (r *OptionDecl) Pos() token.Position { return r.OptionPos }
+func (r *OptionDecl) End() token.Position {
+	if r.Value != nil {
+		return r.Value.End()
+	}
+	return r.Name.End()
+}
+func (r *OptionDecl) String() string {
+	out := "option " + r.Name.String()
+	if r.Value != nil {
+		out += " = " + r.Value.String()
+	}
+	return out + ";"
+}
+func (r *OptionDecl) declNode() {}
+
 func (r *BadDecl) Pos() token.Position { return r.From }
 func (r *BadDecl) End() token.Position { return r.To }
 func (r *BadDecl) String() string {
@@ -274,3 +540,12 @@ func (r *File) String() string {
 	}
 	return out
 }
+
+// docText prefers the lead doc comment and falls back to the trailing line
+// comment, the way go/ast keeps Doc and Comment apart.
+func docText(doc, comment *CommentGroup) string {
+	if text := doc.Text(); text != "" {
+		return text
+	}
+	return comment.Text()
+}
@@ -0,0 +1,215 @@
+package golang
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// formatSource removes unused imports from generated Go source and formats it
+// the way gofmt would.
+func formatSource(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, sourceError(src, err)
+	}
+
+	pruneImports(fset, file)
+	ast.SortImports(fset, file)
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, sourceError(src, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// checkSection parses the declarations rendered for a single enum, reporting
+// errors against the lines of that section.
+func checkSection(section string) error {
+	// The package clause shares the first line so that line numbers match.
+	src := "package p; " + section
+	if _, err := parser.ParseFile(token.NewFileSet(), "", src, parser.SkipObjectResolution); err != nil {
+		return sourceError([]byte(section), err)
+	}
+	return nil
+}
+
+// pruneImports drops imports whose package name is never referenced, along
+// with their comments, and lays out what remains with layoutImports.
+func pruneImports(fset *token.FileSet, file *ast.File) {
+	used := make(map[string]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+
+	removed := make(map[*ast.CommentGroup]bool)
+	imports := file.Imports[:0]
+	decls := file.Decls[:0]
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			decls = append(decls, decl)
+			continue
+		}
+
+		specs := gen.Specs[:0]
+		for _, spec := range gen.Specs {
+			imp := spec.(*ast.ImportSpec)
+			name := importName(imp)
+			if name == "_" || name == "." || used[name] {
+				specs = append(specs, imp)
+				imports = append(imports, imp)
+				continue
+			}
+			removed[imp.Doc] = true
+			removed[imp.Comment] = true
+		}
+		gen.Specs = specs
+
+		if len(gen.Specs) == 0 {
+			removed[gen.Doc] = true
+			continue
+		}
+		layoutImports(fset.File(gen.Pos()), gen)
+		decls = append(decls, gen)
+	}
+	file.Imports = imports
+	file.Decls = decls
+
+	comments := file.Comments[:0]
+	for _, cg := range file.Comments {
+		if !removed[cg] {
+			comments = append(comments, cg)
+		}
+	}
+	file.Comments = comments
+}
+
+// layoutImports rewrites the line table between the parentheses of an import
+// declaration so that the remaining specs sit on consecutive lines, with one
+// blank line between standard library imports and the rest. Lines inside a
+// spec, such as a multi-line doc comment, are kept as they are.
+func layoutImports(tf *token.File, gen *ast.GenDecl) {
+	if !gen.Lparen.IsValid() || !gen.Rparen.IsValid() {
+		return
+	}
+
+	type span struct{ start, end int }
+	spans := make([]span, 0, len(gen.Specs))
+	for _, spec := range gen.Specs {
+		imp := spec.(*ast.ImportSpec)
+		start, end := imp.Pos(), imp.End()
+		if imp.Doc != nil {
+			start = imp.Doc.Pos()
+		}
+		if imp.Comment != nil {
+			end = imp.Comment.End()
+		}
+		spans = append(spans, span{tf.Offset(start), tf.Offset(end)})
+	}
+
+	var lines []int
+	old := tf.Lines()
+	keep := func(from, to int) {
+		for _, offset := range old {
+			if offset > from && offset <= to {
+				lines = append(lines, offset)
+			}
+		}
+	}
+	add := func(offset int) {
+		if n := len(lines); n == 0 || offset > lines[n-1] {
+			lines = append(lines, offset)
+		}
+	}
+
+	lparen, rparen := tf.Offset(gen.Lparen), tf.Offset(gen.Rparen)
+	for _, offset := range old {
+		if offset <= lparen {
+			lines = append(lines, offset)
+		}
+	}
+	for i, sp := range spans {
+		if i > 0 && isStdImport(gen.Specs[i-1]) && !isStdImport(gen.Specs[i]) {
+			add(sp.start - 1)
+		}
+		add(sp.start)
+		keep(sp.start, sp.end)
+	}
+	add(rparen)
+	for _, offset := range old {
+		if offset > rparen {
+			lines = append(lines, offset)
+		}
+	}
+	tf.SetLines(lines)
+}
+
+// isStdImport reports whether spec imports a standard library package, which
+// gofmt convention groups ahead of everything else.
+func isStdImport(spec ast.Spec) bool {
+	importPath, _ := strconv.Unquote(spec.(*ast.ImportSpec).Path.Value)
+	return !strings.Contains(strings.SplitN(importPath, "/", 2)[0], ".")
+}
+
+// importName returns the name an import is referenced by, assuming the package
+// name matches the last path element without any major version suffix.
+func importName(imp *ast.ImportSpec) string {
+	if imp.Name != nil {
+		return imp.Name.Name
+	}
+
+	importPath, err := strconv.Unquote(imp.Path.Value)
+	if err != nil {
+		return ""
+	}
+
+	base := path.Base(importPath)
+	if isMajorVersion(base) {
+		base = path.Base(path.Dir(importPath))
+	}
+	if i := strings.Index(base, ".v"); i > 0 && isMajorVersion(base[i+1:]) {
+		base = base[:i]
+	}
+	return base
+}
+
+func isMajorVersion(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+	_, err := strconv.Atoi(s[1:])
+	return err == nil
+}
+
+// sourceError annotates a parse or format error with the offending line of the
+// generated source, since the position alone points into code the user never sees.
+func sourceError(src []byte, err error) error {
+	var list scanner.ErrorList
+	if !errors.As(err, &list) || len(list) == 0 {
+		return fmt.Errorf("generated code is not valid Go: %w", err)
+	}
+
+	line := list[0].Pos.Line
+	lines := strings.Split(string(src), "\n")
+	if line < 1 || line > len(lines) {
+		return fmt.Errorf("generated code is not valid Go: %w", err)
+	}
+
+	return fmt.Errorf("generated code is not valid Go: %w\n\t%d | %s", err, line, strings.TrimRight(lines[line-1], " \t\r"))
+}
@@ -0,0 +1,134 @@
+package golang
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatSourcePrunesImports(t *testing.T) {
+	src := `package main
+
+import (
+	"strings"
+	"fmt"
+	"encoding/json"
+	"gopkg.in/yaml.v3"
+)
+
+
+var _ = fmt.Sprint(yaml.Node{})
+`
+	out, err := formatSource([]byte(src))
+	if err != nil {
+		t.Fatalf("formatSource failed: %v", err)
+	}
+
+	want := `package main
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+var _ = fmt.Sprint(yaml.Node{})
+`
+	if string(out) != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestFormatSourceClosesImportGaps(t *testing.T) {
+	src := `package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var _ = fmt.Sprint(slices.Max([]int{1}))
+var _ = strings.ToLower
+`
+	out, err := formatSource([]byte(src))
+	if err != nil {
+		t.Fatalf("formatSource failed: %v", err)
+	}
+
+	want := `import (
+	"fmt"
+	"slices"
+	"strings"
+)
+`
+	if !strings.Contains(string(out), want) {
+		t.Errorf("unexpected output:\n%s\nwant imports:\n%s", out, want)
+	}
+}
+
+func TestFormatSourceKeepsImportComments(t *testing.T) {
+	src := `package main
+
+import (
+	// fmt is used for printing.
+	"fmt"
+	"strings" // strings is never used.
+	// json is never used either.
+	"encoding/json"
+
+	"gopkg.in/yaml.v3" // yaml decodes nodes.
+)
+
+var _ = fmt.Sprint(yaml.Node{})
+`
+	out, err := formatSource([]byte(src))
+	if err != nil {
+		t.Fatalf("formatSource failed: %v", err)
+	}
+
+	want := `package main
+
+import (
+	// fmt is used for printing.
+	"fmt"
+
+	"gopkg.in/yaml.v3" // yaml decodes nodes.
+)
+
+var _ = fmt.Sprint(yaml.Node{})
+`
+	if string(out) != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestFormatSourceReportsLine(t *testing.T) {
+	src := "package main\n\nfunc broken( {\n}\n"
+
+	_, err := formatSource([]byte(src))
+	if err == nil {
+		t.Fatal("expected an error for invalid source")
+	}
+	if !strings.Contains(err.Error(), "3 | func broken( {") {
+		t.Errorf("expected the error to quote the failing line, got: %v", err)
+	}
+}
+
+func TestCheckSectionReportsLine(t *testing.T) {
+	section := "// Color is broken.\ntype Color int\n\nconst (\n\tRed Color = = 1\n)\n"
+
+	err := checkSection(section)
+	if err == nil {
+		t.Fatal("expected an error for an invalid section")
+	}
+	if !strings.Contains(err.Error(), "5 | \tRed Color = = 1") {
+		t.Errorf("expected the error to quote line 5 of the section, got: %v", err)
+	}
+	if err := checkSection("// Color is fine.\ntype Color int\n"); err != nil {
+		t.Errorf("unexpected error for a valid section: %v", err)
+	}
+}
@@ -4,14 +4,17 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/kkumar-gcc/enumgen/src/version"
+	gotoken "go/token"
 	"maps"
-	"path/filepath"
+	"strconv"
 	"strings"
 	"text/template"
 
+	"github.com/kkumar-gcc/enumgen/pkg/strcase"
 	"github.com/kkumar-gcc/enumgen/pkg/strconvx"
 	"github.com/kkumar-gcc/enumgen/src/codegen/contracts"
 	"github.com/kkumar-gcc/enumgen/src/codegen/golang/types"
+	"github.com/kkumar-gcc/enumgen/src/codegen/output"
 	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
 )
 
@@ -19,6 +22,7 @@ var _ contracts.Generator = (*Generator)(nil)
 
 type Generator struct {
 	templates       map[Style]*template.Template
+	file            *template.Template
 	valueFormatters map[string]types.ValueFormatter
 }
 
@@ -27,9 +31,14 @@ func New() (*Generator, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to load templates: %w", err)
 	}
+	file, err := loadTemplate("file", fileTemplate, templatesFS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load templates: %w", err)
+	}
 
 	return &Generator{
 		templates:       templates,
+		file:            file,
 		valueFormatters: defaultValueFormatters(), // Renamed
 	}, nil
 }
@@ -61,21 +70,62 @@ func (g *Generator) OptionHelp() string {
 }
 
 func (g *Generator) Generate(module compiler.IRModule, options map[string]string) ([]*compiler.OutputFile, error) {
-	opts := g.DefaultOptions()
+	opts := maps.Clone(g.DefaultOptions())
 	maps.Copy(opts, options)
 
-	files := make([]*compiler.OutputFile, 0, len(module.Enums()))
-	for _, enum := range module.Enums() {
-		fileName := generateFileName(enum.Name())
-		filePath := filepath.Join(fileName)
+	outputMode, pattern, err := output.Resolve(opts[OptionOutputMode], opts[OptionFileName], "_gen.go")
+	if err != nil {
+		return nil, err
+	}
 
+	if ParseUnknownMode(opts[OptionJSONUnknown]) == UnknownInvalid {
+		return nil, fmt.Errorf("unknown %s mode '%s'", OptionJSONUnknown, opts[OptionJSONUnknown])
+	}
+
+	enums := make([]string, 0, len(module.Enums()))
+	for _, enum := range module.Enums() {
 		code, err := g.generateEnum(enum, opts)
 		if err != nil {
 			return nil, fmt.Errorf("failed to generate code for enum '%s': %w", enum.Name(), err)
 		}
+		enums = append(enums, code)
+	}
+
+	if outputMode == output.Single {
+		if len(enums) == 0 {
+			return nil, nil
+		}
+
+		fileName := output.FileName(pattern, module.Name(), "")
+		code, err := g.generateFile(module.Doc(), enums, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate file '%s': %w", fileName, err)
+		}
+		return []*compiler.OutputFile{{Path: fileName, Body: code}}, nil
+	}
+
+	fileNames, err := output.FileNames(pattern, module)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*compiler.OutputFile, 0, len(enums))
+	for i, enum := range module.Enums() {
+		fileName := fileNames[enum.Name()]
+
+		// The package doc is emitted once, in the first generated file.
+		packageDoc := ""
+		if i == 0 {
+			packageDoc = module.Doc()
+		}
+
+		code, err := g.generateFile(packageDoc, enums[i:i+1], opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate code for enum '%s': %w", enum.Name(), err)
+		}
 
 		files = append(files, &compiler.OutputFile{
-			Path: filePath,
+			Path: fileName,
 			Body: code,
 		})
 	}
@@ -83,36 +133,91 @@ func (g *Generator) Generate(module compiler.IRModule, options map[string]string
 	return files, nil
 }
 
-func (g *Generator) generateEnum(enum compiler.IREnumDefinition, options map[string]string) ([]byte, error) {
+// generateFile wraps rendered enums with the file header and imports, and
+// formats the result.
+func (g *Generator) generateFile(packageDoc string, enums []string, options map[string]string) ([]byte, error) {
+	data := FileData{
+		EDLVersion:  version.Version,
+		ToolVersion: Version,
+		Package:     options[OptionPackage],
+		PackageDoc:  packageDoc,
+		Enums:       enums,
+	}
+
+	var buf bytes.Buffer
+	if err := g.file.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	code, err := formatSource(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("template %s: %w", fileTemplate, err)
+	}
+
+	return code, nil
+}
+
+// generateEnum renders the declarations of a single enum, without the file
+// header and imports.
+func (g *Generator) generateEnum(enum compiler.IREnumDefinition, options map[string]string) (string, error) {
 	enumStyle, _ := options[OptionEnumStyle]
 	templateName := ParseStyle(enumStyle)
+	if templateName == StyleUnknown {
+		return "", fmt.Errorf("unknown enum style '%s'", enumStyle)
+	}
+	if enum.IsFlags() {
+		templateName = StyleFlags
+	}
+	if len(enum.Fields()) > 0 {
+		// Tuple members carry several values, which only the struct style can
+		// hold. GoEnumStyleRule warns when another style was requested.
+		templateName = StyleStandard
+	}
+	if templateName == StyleConst && enum.KeyType() != nil {
+		return "", fmt.Errorf("enum style '%s' requires a single value type, but '%s' declares a key type", templateName, enum.Name())
+	}
 
-	data, err := g.prepareTemplateData(enum, options)
+	data, err := g.prepareTemplateData(enum, templateName, options)
 	if err != nil {
-		return nil, fmt.Errorf("failed to prepare data: %w", err)
+		return "", fmt.Errorf("failed to prepare data: %w", err)
 	}
 
 	tmpl, ok := g.templates[templateName]
 	if !ok {
-		return nil, fmt.Errorf("template for style '%s' not found", templateName)
+		return "", fmt.Errorf("template for style '%s' not found", templateName)
 	}
 
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, data); err != nil {
-		return nil, fmt.Errorf("failed to execute template: %w", err)
+		return "", fmt.Errorf("failed to execute template: %w", err)
 	}
 
-	return buf.Bytes(), nil
+	// Checking each enum on its own points a syntax error at the enum and
+	// style that produced it, not at the file the enums are merged into.
+	if err := checkSection(buf.String()); err != nil {
+		return "", fmt.Errorf("style '%s' (template %s): %w", templateName, defaultTemplates[templateName], err)
+	}
+
+	return buf.String(), nil
 }
 
-func (g *Generator) prepareTemplateData(enum compiler.IREnumDefinition, options map[string]string) (*TemplateData, error) {
-	valueType := enum.ValueType()
-	if valueType == nil {
-		return nil, fmt.Errorf("enum '%s' has no value type defined", enum.Name())
+func (g *Generator) prepareTemplateData(enum compiler.IREnumDefinition, style Style, options map[string]string) (*TemplateData, error) {
+	fields, err := g.prepareFields(enum)
+	if err != nil {
+		return nil, err
 	}
-	valueFormatter := g.getValueFormatter(valueType.String())
+
+	valueTypeName := "string" // tuple members are keyed by their names
+	if fields == nil {
+		valueType := enum.ValueType()
+		if valueType == nil {
+			return nil, fmt.Errorf("enum '%s' has no value type defined", enum.Name())
+		}
+		valueTypeName = valueType.String()
+	}
+	valueFormatter := g.getValueFormatter(valueTypeName)
 	if valueFormatter == nil {
-		return nil, fmt.Errorf("unsupported value type '%s' for enum '%s'", valueType.String(), enum.Name())
+		return nil, fmt.Errorf("unsupported value type '%s' for enum '%s'", valueTypeName, enum.Name())
 	}
 
 	keyFormatter := valueFormatter
@@ -123,16 +228,35 @@ func (g *Generator) prepareTemplateData(enum compiler.IREnumDefinition, options
 		}
 	}
 
+	prefix := strconvx.ToBool(options[OptionPrefixEnumName], false)
+	idents := make(map[string]string, len(enum.Members()))
+
 	members := make([]TemplateMember, 0, len(enum.Members()))
 	for i, member := range enum.Members() {
+		ident := memberIdent(enum.Name(), member.Name(), prefix)
+		if ident == enum.Name() {
+			return nil, fmt.Errorf("member '%s' cannot be named after its enum", member.Name())
+		}
+		if prev, ok := idents[ident]; ok {
+			return nil, fmt.Errorf("members '%s' and '%s' both map to the Go identifier '%s'", prev, member.Name(), ident)
+		}
+		idents[ident] = member.Name()
+
 		var keyIR, valueIR compiler.IRValue
+		var fieldValues []any
 
-		if kv, ok := member.Value().(compiler.IRKeyValue); ok {
-			keyIR = kv.Key()
-			valueIR = kv.Value()
-		} else {
-			keyIR = member.Value()
-			valueIR = member.Value()
+		switch v := member.Value().(type) {
+		case compiler.IRKeyValue:
+			keyIR = referencedValue(v.Key())
+			valueIR = referencedValue(v.Value())
+		case compiler.IRTuple:
+			fieldValues, err = g.formatTuple(enum, v, member.Name(), prefix)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			keyIR = referencedValue(member.Value())
+			valueIR = keyIR
 		}
 
 		formattedKey, err := keyFormatter.FormatMemberValue(keyIR, member.Name(), i)
@@ -146,29 +270,311 @@ func (g *Generator) prepareTemplateData(enum compiler.IREnumDefinition, options
 		}
 
 		members = append(members, TemplateMember{
-			Name:  member.Name(),
-			Doc:   member.Doc(),
-			Key:   formattedKey,
-			Value: formattedValue,
+			Name:    member.Name(),
+			Ident:   ident,
+			Doc:     member.Doc(),
+			Comment: member.Comment(),
+			Index:   i,
+			Key:     formattedKey,
+			Value:   formattedValue,
+			Fields:  fieldValues,
 		})
 	}
 
-	return &TemplateData{
-		EDLVersion:       version.Version,
-		ToolVersion:      Version,
-		Package:          options[OptionPackage],
+	aliases, err := memberAliases(enum, prefix, idents)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &TemplateData{
 		EnumName:         enum.Name(),
+		IsFlags:          style == StyleFlags,
+		Receiver:         "e",
+		ValueMethod:      "Value",
 		EnumDoc:          enum.Doc(),
+		UnderlyingType:   valueFormatter.GoTypeName(),
 		KeyType:          keyFormatter.GoTypeName(),
 		ValueType:        valueFormatter.GoTypeName(),
 		KeyZeroValue:     keyFormatter.ZeroValue(),
 		ValueZeroValue:   valueFormatter.ZeroValue(),
 		Members:          members,
+		MemberAliases:    aliases,
+		MemberDecl:       "const",
+		Fields:           fields,
 		GenerateStringer: strconvx.ToBool(options[OptionGenerateStringer], false),
 		GenerateJSON:     strconvx.ToBool(options[OptionGenerateJSON], false),
-		PrefixEnumName:   strconvx.ToBool(options[OptionPrefixEnumName], false),
+		PrefixEnumName:   prefix,
 		GenerateMap:      strconvx.ToBool(options[OptionGenerateMap], false),
-	}, nil
+		GenerateText:     strconvx.ToBool(options[OptionGenerateText], false),
+		GenerateYAML:     strconvx.ToBool(options[OptionGenerateYAML], false),
+		GenerateFlag:     strconvx.ToBool(options[OptionGenerateFlag], false),
+		GenerateSQL:      strconvx.ToBool(options[OptionGenerateSQL], false),
+	}
+
+	for _, f := range fields {
+		data.UniqueFields = data.UniqueFields || f.Unique
+	}
+
+	if data.GenerateSQL {
+		// driver.Valuer claims the Value method, so the accessor is renamed.
+		data.ValueMethod = "RawValue"
+	}
+
+	switch style {
+	case StyleStandard:
+		data.MemberDecl = "var"
+	case StyleIota:
+		data.UnderlyingType = "int"
+	case StyleFlags:
+		// Flag sets are keyed by their member names, e.g. "READ|WRITE".
+		if _, ok := valueFormatter.(*types.IntFormatter); !ok {
+			return nil, fmt.Errorf("flags enum '%s' requires an integer value type, got '%s'", enum.Name(), valueTypeName)
+		}
+		data.KeyType = "string"
+		data.KeyZeroValue = ""
+		for i := range data.Members {
+			data.Members[i].Key = data.Members[i].Name
+		}
+		data.Receiver = "f"
+	}
+
+	if err := prepareLookups(data, enum, style, options); err != nil {
+		return nil, err
+	}
+
+	prepareUnknown(data, enum, style, ParseUnknownMode(options[OptionJSONUnknown]))
+	prepareAnnotations(data, enum)
+
+	data.ValidKeys = "one of: " + keyList(data.Members)
+	if style == StyleFlags {
+		data.ValidKeys = "a '|' separated list of: " + keyList(data.Members)
+	}
+
+	if data.GenerateFlag {
+		// flag.Value requires a String method.
+		data.GenerateStringer = true
+	}
+
+	if data.GenerateText || data.GenerateFlag {
+		// The text form of an enum is its key; for const enums that is the value.
+		text, err := prepareEncoding(data, "key")
+		if err != nil {
+			return nil, err
+		}
+		data.Text = text
+	}
+
+	if data.GenerateSQL {
+		sql, err := prepareEncoding(data, options[OptionSQLColumn])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s option: %w", OptionSQLColumn, err)
+		}
+		data.SQL = sql
+	}
+
+	return data, nil
+}
+
+// reservedAccessors are methods of generated enums that a field accessor
+// must not replace.
+var reservedAccessors = map[string]bool{
+	"Key": true, "String": true, "Label": true, "Set": true, "Type": true,
+	"Scan": true, "Value": true, "RawValue": true,
+	"MarshalText": true, "UnmarshalText": true, "AppendText": true,
+	"MarshalJSON": true, "UnmarshalJSON": true,
+	"MarshalYAML": true, "UnmarshalYAML": true,
+}
+
+// prepareFields returns the fields of a tuple enum, or nil for other enums.
+func (g *Generator) prepareFields(enum compiler.IREnumDefinition) ([]TemplateField, error) {
+	if len(enum.Fields()) == 0 {
+		return nil, nil
+	}
+
+	fields := make([]TemplateField, 0, len(enum.Fields()))
+	owners := make(map[string]string)
+	for _, field := range enum.Fields() {
+		if field.Type() == nil {
+			return nil, fmt.Errorf("field '%s' of enum '%s' has no type", field.Name(), enum.Name())
+		}
+		formatter := g.fieldFormatter(field, false)
+		if formatter == nil {
+			return nil, fmt.Errorf("unsupported type '%s' of field '%s' in enum '%s'", field.Type().String(), field.Name(), enum.Name())
+		}
+
+		typeName := formatter.GoTypeName()
+		if field.Type().Kind() == compiler.TypeEnum {
+			typeName = field.Type().Name()
+		}
+
+		accessor := strcase.ToPascal(field.Name())
+		if reservedAccessors[accessor] {
+			return nil, fmt.Errorf("field '%s' of enum '%s' would replace the generated method '%s'", field.Name(), enum.Name(), accessor)
+		}
+		if prev, ok := owners[accessor]; ok {
+			return nil, fmt.Errorf("fields '%s' and '%s' of enum '%s' both map to the method '%s'", prev, field.Name(), enum.Name(), accessor)
+		}
+		owners[accessor] = field.Name()
+
+		ident := strcase.ToCamel(field.Name())
+		if gotoken.IsKeyword(ident) {
+			ident += "_"
+		}
+
+		fields = append(fields, TemplateField{
+			Name:     field.Name(),
+			Ident:    ident,
+			Accessor: accessor,
+			Type:     typeName,
+			Unique:   field.IsUnique(),
+		})
+	}
+	return fields, nil
+}
+
+// fieldFormatter returns the formatter for the values of a tuple field. Fields
+// of an enum type hold references to its members.
+func (g *Generator) fieldFormatter(field compiler.IRField, prefix bool) types.ValueFormatter {
+	if field.Type().Kind() == compiler.TypeEnum {
+		return &referenceFormatter{enum: field.Type().Name(), prefix: prefix}
+	}
+	return g.getValueFormatter(field.Type().String())
+}
+
+// formatTuple formats the values of a tuple member with the formatters of
+// their fields.
+func (g *Generator) formatTuple(enum compiler.IREnumDefinition, tuple compiler.IRTuple, memberName string, prefix bool) ([]any, error) {
+	fields := enum.Fields()
+	if len(tuple.Elements()) != len(fields) {
+		return nil, fmt.Errorf("member '%s' has %d values, but enum '%s' declares %d fields", memberName, len(tuple.Elements()), enum.Name(), len(fields))
+	}
+
+	values := make([]any, len(fields))
+	for i, elt := range tuple.Elements() {
+		formatter := g.fieldFormatter(fields[i], prefix)
+		if _, isRef := formatter.(*referenceFormatter); !isRef {
+			elt = referencedValue(elt)
+		}
+		formatted, err := formatter.FormatMemberValue(elt, memberName, i)
+		if err != nil {
+			return nil, fmt.Errorf("error formatting field '%s' of member '%s': %w", fields[i].Name(), memberName, err)
+		}
+		values[i] = formatted
+	}
+	return values, nil
+}
+
+// memberAliases returns the members of the enum that alias another member.
+// Their identifiers are added to idents, which holds those already taken.
+func memberAliases(enum compiler.IREnumDefinition, prefix bool, idents map[string]string) ([]TemplateMemberAlias, error) {
+	aliases := make([]TemplateMemberAlias, 0, len(enum.MemberAliases()))
+	for _, member := range enum.MemberAliases() {
+		ref, ok := member.Value().(compiler.IRReference)
+		if !ok {
+			return nil, fmt.Errorf("member '%s' is not assigned another member", member.Name())
+		}
+
+		ident := memberIdent(enum.Name(), member.Name(), prefix)
+		if prev, ok := idents[ident]; ok {
+			return nil, fmt.Errorf("members '%s' and '%s' both map to the Go identifier '%s'", prev, member.Name(), ident)
+		}
+		idents[ident] = member.Name()
+
+		aliases = append(aliases, TemplateMemberAlias{
+			Name:       member.Name(),
+			Ident:      ident,
+			Target:     memberIdent(enum.Name(), ref.Member(), prefix),
+			Doc:        member.Doc(),
+			Comment:    member.Comment(),
+			Deprecated: deprecation(templateAnnotations(member.Annotations()), ident),
+		})
+	}
+	return aliases, nil
+}
+
+// referencedValue returns the value a member reference stands for, or v
+// itself if it is not a reference.
+func referencedValue(v compiler.IRValue) compiler.IRValue {
+	if ref, ok := v.(compiler.IRReference); ok {
+		return ref.Target()
+	}
+	return v
+}
+
+// prepareUnknown sets up the @default member of the enum and how UnmarshalJSON
+// treats keys that match no member.
+func prepareUnknown(data *TemplateData, enum compiler.IREnumDefinition, style Style, mode UnknownMode) {
+	if def := enum.DefaultMember(); def != nil {
+		for _, m := range data.Members {
+			if m.Name == def.Name() {
+				data.DefaultMember = m.Ident
+			}
+		}
+	}
+
+	if mode == UnknownPreserve && data.KeyType == "string" {
+		switch style {
+		case StyleStandard:
+			data.UnknownKey = data.EnumName + "{key: k}"
+		case StyleConst:
+			data.UnknownKey = data.EnumName + "(k)"
+		}
+		data.PreserveUnknown = data.UnknownKey != ""
+	}
+
+	if !data.PreserveUnknown && mode != UnknownError {
+		data.UnknownKey = data.DefaultMember
+	}
+}
+
+// prepareAnnotations copies the annotations of the enum and its members into
+// the template data and derives deprecation notices, labels and JSON names.
+func prepareAnnotations(data *TemplateData, enum compiler.IREnumDefinition) {
+	data.Annotations = templateAnnotations(enum.Annotations())
+	data.EnumDeprecated = deprecation(data.Annotations, data.EnumName)
+
+	for i, member := range enum.Members() {
+		m := &data.Members[i]
+		m.Annotations = templateAnnotations(member.Annotations())
+		m.Deprecated = deprecation(m.Annotations, m.Ident)
+		if a, ok := findAnnotation(m.Annotations, "label"); ok && len(a.Args) > 0 {
+			m.Label = a.Args[0]
+			data.HasLabels = true
+		}
+		if a, ok := findAnnotation(m.Annotations, "json"); ok && len(a.Args) > 0 {
+			m.JSONName = a.Args[0]
+			data.JSONNames = true
+		}
+	}
+}
+
+func templateAnnotations(annotations []compiler.IRAnnotation) []Annotation {
+	result := make([]Annotation, 0, len(annotations))
+	for _, a := range annotations {
+		result = append(result, Annotation{Name: a.Name(), Args: a.Args()})
+	}
+	return result
+}
+
+func findAnnotation(annotations []Annotation, name string) (Annotation, bool) {
+	for _, a := range annotations {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Annotation{}, false
+}
+
+// deprecation returns the deprecation notice of a @deprecated declaration named ident, or "".
+func deprecation(annotations []Annotation, ident string) string {
+	a, ok := findAnnotation(annotations, "deprecated")
+	if !ok {
+		return ""
+	}
+	if len(a.Args) > 0 && a.Args[0] != "" {
+		return a.Args[0]
+	}
+	return ident + " should no longer be used."
 }
 
 func (g *Generator) getValueFormatter(enumType string) types.ValueFormatter {
@@ -198,6 +604,112 @@ func defaultValueFormatters() map[string]types.ValueFormatter {
 	}
 }
 
-func generateFileName(enumName string) string {
-	return fmt.Sprintf("%s_gen.go", strings.ToLower(enumName))
+// prepareEncoding selects the key or the value of an enum as the
+// representation used by an encoding.
+func prepareEncoding(data *TemplateData, source string) (Encoding, error) {
+	enc := Encoding{Source: source}
+	switch source {
+	case "key":
+		enc.Type = data.KeyType
+		enc.Parse = "Parse" + data.EnumName + "Key"
+		enc.Accessor = "Key"
+	case "value":
+		if len(data.Fields) > 0 {
+			return Encoding{}, fmt.Errorf("tuple enum '%s' has no single value, use 'key'", data.EnumName)
+		}
+		enc.Type = data.ValueType
+		enc.Parse = "Parse" + data.EnumName + "Value"
+		enc.Accessor = data.ValueMethod
+	default:
+		return Encoding{}, fmt.Errorf("unknown representation '%s', expected 'key' or 'value'", source)
+	}
+
+	switch {
+	case enc.Type == "string" || enc.Type == "bool":
+		enc.Kind = enc.Type
+	case strings.HasPrefix(enc.Type, "float"):
+		enc.Kind = "float"
+	case strings.HasPrefix(enc.Type, "uint"):
+		enc.Kind = "uint"
+	default:
+		enc.Kind = "int"
+	}
+
+	switch enc.Type {
+	case "rune":
+		enc.Bits = 32
+	default:
+		enc.Bits, _ = strconv.Atoi(strings.TrimLeft(enc.Type, "abcdefghijklmnopqrstuvwxyz"))
+	}
+
+	return enc, nil
+}
+
+// prepareLookups collects the keys each member is parsed from. String keys may
+// be extended by aliases and folded to lower case.
+func prepareLookups(data *TemplateData, enum compiler.IREnumDefinition, style Style, options map[string]string) error {
+	data.KeyTable = data.EnumName + "KeyMap"
+	if style == StyleConst {
+		// Typed-constant enums are keyed by their values.
+		data.KeyTable = data.EnumName + "ValueMap"
+	}
+
+	stringKeys := data.KeyType == "string"
+	data.CaseInsensitive = stringKeys && strconvx.ToBool(options[OptionCaseInsensitive], false)
+	data.TrimSpace = stringKeys && strconvx.ToBool(options[OptionTrimSpace], false)
+
+	owners := make(map[any]string)
+	for i, member := range enum.Members() {
+		m := &data.Members[i]
+		aliases := member.Aliases()
+		if len(aliases) > 0 && !stringKeys {
+			return fmt.Errorf("member '%s' declares aliases, but the key type is '%s'", member.Name(), data.KeyType)
+		}
+		data.HasAliases = data.HasAliases || len(aliases) > 0
+
+		keys := []any{m.Key}
+		for _, alias := range aliases {
+			keys = append(keys, alias)
+		}
+
+		m.Lookups = m.Lookups[:0]
+		for _, key := range keys {
+			if s, ok := key.(string); ok && data.CaseInsensitive {
+				key = strings.ToLower(s)
+			}
+			if owner, exists := owners[key]; exists {
+				if owner == m.Name {
+					continue
+				}
+				return fmt.Errorf("key %#v of member '%s' is also accepted for member '%s'", key, m.Name, owner)
+			}
+			owners[key] = m.Name
+			m.Lookups = append(m.Lookups, key)
+		}
+	}
+	data.KeyLookup = data.HasAliases || data.CaseInsensitive
+
+	return nil
+}
+
+// keyList renders the keys of all members for error messages.
+func keyList(members []TemplateMember) string {
+	keys := make([]string, len(members))
+	for i, m := range members {
+		if r, ok := m.Key.(rune); ok {
+			keys[i] = string(r)
+			continue
+		}
+		keys[i] = fmt.Sprint(m.Key)
+	}
+	return strings.Join(keys, ", ")
+}
+
+// memberIdent returns the Go identifier of an enum member. Prefixed members
+// are converted to PascalCase, e.g. DARK_BLUE of Color becomes ColorDarkBlue.
+func memberIdent(enumName, memberName string, prefix bool) string {
+	if !prefix {
+		return memberName
+	}
+	return enumName + strcase.ToPascal(memberName)
 }
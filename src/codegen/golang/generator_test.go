@@ -0,0 +1,1016 @@
+package golang_test
+
+import (
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kkumar-gcc/enumgen/src/codegen"
+	"github.com/kkumar-gcc/enumgen/src/compiler"
+	contracts "github.com/kkumar-gcc/enumgen/src/contracts/compiler"
+)
+
+const sampleSource = `
+enum Status [int]:
+    SUCCESS = 0,
+    WARNING = 1,
+    ERROR = 2;
+
+enum Color [string]:
+    RED = "red",
+    GREEN = "green",
+    BLUE = "blue";
+
+enum Day [string, string]:
+    MONDAY = "Monday":"Mon",
+    TUESDAY = "Tuesday":"Tue";
+`
+
+// generate compiles src with the Go generator and returns the generated files.
+func generate(t *testing.T, src string, options map[string]string) []*contracts.OutputFile {
+	t.Helper()
+	codegen.Init()
+
+	path := filepath.Join(t.TempDir(), "enums.edl")
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatalf("failed to write source: %v", err)
+	}
+
+	ctx, err := compiler.CompileFile(path, "", "go", false, options)
+	if err != nil {
+		t.Fatalf("compilation failed: %v", err)
+	}
+	if ctx.Validations.HasErrors() {
+		t.Fatalf("validation failed:\n%s", ctx.Validations.String())
+	}
+	return ctx.OutputFiles
+}
+
+// typeCheck verifies the generated files are gofmt-formatted, then parses and
+// type-checks them as a single package.
+func typeCheck(t *testing.T, files []*contracts.OutputFile) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	parsed := make([]*ast.File, 0, len(files))
+	for _, file := range files {
+		if formatted, err := format.Source(file.Body); err != nil || string(formatted) != string(file.Body) {
+			t.Errorf("generated file %s is not gofmt-formatted", file.Path)
+		}
+
+		f, err := parser.ParseFile(fset, file.Path, file.Body, parser.ParseComments)
+		if err != nil {
+			t.Fatalf("generated file %s does not parse: %v\n%s", file.Path, err, file.Body)
+		}
+		parsed = append(parsed, f)
+	}
+
+	conf := types.Config{Importer: stubImporter{importer.ForCompiler(fset, "source", nil)}}
+	if _, err := conf.Check("main", fset, parsed, nil); err != nil {
+		t.Fatalf("generated code does not type-check: %v", err)
+	}
+}
+
+// yamlStub stands in for gopkg.in/yaml.v3, which this module does not depend on.
+const yamlStub = `package yaml
+
+type Node struct {
+	Line  int
+	Value string
+}
+
+func (n *Node) Decode(v any) error { return nil }
+`
+
+type stubImporter struct {
+	types.Importer
+}
+
+func (i stubImporter) Import(path string) (*types.Package, error) {
+	if path != "gopkg.in/yaml.v3" {
+		return i.Importer.Import(path)
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "yaml.go", yamlStub, 0)
+	if err != nil {
+		return nil, err
+	}
+	return (&types.Config{}).Check(path, fset, []*ast.File{f}, nil)
+}
+
+func body(files []*contracts.OutputFile) string {
+	var sb strings.Builder
+	for _, file := range files {
+		sb.Write(file.Body)
+	}
+	return sb.String()
+}
+
+func TestGenerateStyles(t *testing.T) {
+	for _, style := range []string{"standard", "iota"} {
+		t.Run(style, func(t *testing.T) {
+			files := generate(t, sampleSource, map[string]string{"enum_style": style})
+			if len(files) != 3 {
+				t.Fatalf("expected 3 files, got %d", len(files))
+			}
+			typeCheck(t, files)
+		})
+	}
+}
+
+func TestGenerateIotaStyle(t *testing.T) {
+	out := body(generate(t, sampleSource, map[string]string{"enum_style": "iota"}))
+
+	for _, want := range []string{
+		"type Status int",
+		"SUCCESS Status = iota",
+		"func StatusValues() []Status",
+		"func (e Color) IsValid() bool",
+		"func ParseDayKey(key string) (Day, error)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected generated code to contain %q", want)
+		}
+	}
+}
+
+func TestGenerateConstStyle(t *testing.T) {
+	src := `
+enum Status [int8]:
+    SUCCESS = 0,
+    WARNING = 1,
+    ERROR = 2;
+
+enum Color [string]:
+    RED = "red",
+    GREEN = "green";
+`
+	files := generate(t, src, map[string]string{"enum_style": "const"})
+	typeCheck(t, files)
+
+	out := body(files)
+	for _, want := range []string{
+		"type Status int8",
+		"SUCCESS Status = 0",
+		"type Color string",
+		`RED Color = "red"`,
+		"func ParseColorValue(val string) (Color, error)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected generated code to contain %q", want)
+		}
+	}
+}
+
+func TestGenerateFlags(t *testing.T) {
+	src := `
+enum Perm [uint8] flags:
+    READ,
+    WRITE,
+    EXEC = 0x8;
+`
+	files := generate(t, src, nil)
+	typeCheck(t, files)
+
+	out := body(files)
+	for _, want := range []string{
+		"type Perm uint8",
+		"READ Perm = 0x1",
+		"WRITE Perm = 0x2",
+		"EXEC Perm = 0x8",
+		"func (f Perm) Has(flag Perm) bool",
+		"func (f Perm) Toggle(flag Perm) Perm",
+		"func ParsePermKey(key string) (Perm, error)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected generated code to contain %q", want)
+		}
+	}
+}
+
+func TestGenerateNegativeLiterals(t *testing.T) {
+	src := `
+enum Code [int8]:
+    ERROR = -1,
+    OK = 0,
+    LOW = -128;
+
+enum Temp [float64, int]:
+    COLD = -1.5:-10,
+    HOT = 2.5:40;
+`
+	files := generate(t, src, nil)
+	typeCheck(t, files)
+
+	out := body(files)
+	for _, want := range []string{
+		"-128: LOW,",
+		"-1.5: COLD,",
+		"-10: COLD,",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected generated code to contain %q", want)
+		}
+	}
+}
+
+func TestGenerateTypelessEnums(t *testing.T) {
+	src := `
+enum Direction:
+    NORTH,
+    EAST;
+`
+	out := body(generate(t, src, map[string]string{"enum_style": "const"}))
+	if !strings.Contains(out, `NORTH Direction = "NORTH"`) {
+		t.Errorf("expected member names as string values, got:\n%s", out)
+	}
+
+	files := generate(t, "option default_type = int;\n"+src, map[string]string{"enum_style": "const"})
+	typeCheck(t, files)
+	if out := body(files); !strings.Contains(out, "EAST Direction = 1") {
+		t.Errorf("expected sequential integer values, got:\n%s", out)
+	}
+}
+
+func TestGenerateDocComments(t *testing.T) {
+	src := `// Package main holds shared enums.
+
+// Color defines standard colors.
+enum Color [string]:
+    // RED is the primary colour.
+    RED = "red", // warm
+    GREEN = "green"; // cool
+`
+	files := generate(t, src, nil)
+	typeCheck(t, files)
+
+	out := body(files)
+	for _, want := range []string{
+		"// Package main holds shared enums.\npackage main",
+		"// Color defines standard colors.\n//\n//enumgen:enum\ntype Color struct",
+		"\t// RED is the primary colour.\n\tRED = Color{ // warm\n",
+		"\t// cool\n\tGREEN = Color{\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected generated code to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "// \n") {
+		t.Errorf("generated code contains empty comment lines:\n%s", out)
+	}
+
+	out = body(generate(t, src, map[string]string{"enum_style": "const"}))
+	if want := "\t// RED is the primary colour.\n\tRED Color = \"red\" // warm\n"; !strings.Contains(out, want) {
+		t.Errorf("expected generated code to contain %q, got:\n%s", want, out)
+	}
+}
+
+func TestGenerateUnknownStyle(t *testing.T) {
+	codegen.Init()
+
+	path := filepath.Join(t.TempDir(), "enums.edl")
+	if err := os.WriteFile(path, []byte(sampleSource), 0644); err != nil {
+		t.Fatalf("failed to write source: %v", err)
+	}
+
+	if _, err := compiler.CompileFile(path, "", "go", false, map[string]string{"enum_style": "bogus"}); err == nil {
+		t.Fatal("expected an error for an unknown enum style")
+	}
+}
+
+func TestGenerateSingleOutput(t *testing.T) {
+	for _, style := range []string{"standard", "iota"} {
+		t.Run(style, func(t *testing.T) {
+			files := generate(t, sampleSource, map[string]string{"output_mode": "single", "enum_style": style})
+			if len(files) != 1 {
+				t.Fatalf("expected 1 file, got %d", len(files))
+			}
+			if files[0].Path != "enums_gen.go" {
+				t.Errorf("expected file enums_gen.go, got %s", files[0].Path)
+			}
+			typeCheck(t, files)
+
+			out := string(files[0].Body)
+			for _, want := range []string{"package main", `"fmt"`, "// Code generated by enumgen"} {
+				if n := strings.Count(out, want); n != 1 {
+					t.Errorf("expected %q once, found %d times", want, n)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateFileNamePattern(t *testing.T) {
+	files := generate(t, sampleSource, map[string]string{"file_name": "enum_{enum}.go"})
+	var paths []string
+	for _, file := range files {
+		paths = append(paths, file.Path)
+	}
+	if got := strings.Join(paths, ","); got != "enum_status.go,enum_color.go,enum_day.go" {
+		t.Errorf("unexpected file names: %s", got)
+	}
+
+	files = generate(t, sampleSource, map[string]string{"output_mode": "single", "file_name": "{file}_enums.go"})
+	if len(files) != 1 || files[0].Path != "enums_enums.go" {
+		t.Errorf("unexpected single output file: %v", files)
+	}
+}
+
+func TestGenerateFileNameCollision(t *testing.T) {
+	codegen.Init()
+
+	path := filepath.Join(t.TempDir(), "enums.edl")
+	src := "enum Color [string]:\n    RED = \"red\";\n\nenum COLOR [string]:\n    BLUE = \"blue\";\n"
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatalf("failed to write source: %v", err)
+	}
+
+	_, err := compiler.CompileFile(path, "", "go", false, nil)
+	if err == nil || !strings.Contains(err.Error(), "enums 'Color' and 'COLOR' both map to the file 'color_gen.go'") {
+		t.Errorf("expected a file name collision error, got: %v", err)
+	}
+}
+
+func TestGenerateInvalidOutputOptions(t *testing.T) {
+	codegen.Init()
+
+	path := filepath.Join(t.TempDir(), "enums.edl")
+	if err := os.WriteFile(path, []byte(sampleSource), 0644); err != nil {
+		t.Fatalf("failed to write source: %v", err)
+	}
+
+	for _, options := range []map[string]string{
+		{"output_mode": "bogus"},
+		{"file_name": "enums_gen.go"},
+		{"output_mode": "single", "file_name": "{enum}_gen.go"},
+	} {
+		if _, err := compiler.CompileFile(path, "", "go", false, options); err == nil {
+			t.Errorf("expected an error for options %v", options)
+		}
+	}
+}
+
+func TestGeneratePrefixedMembers(t *testing.T) {
+	src := `
+enum Color [string]:
+    DARK_BLUE = "dark_blue",
+    lightGreen = "light_green";
+
+enum Perm [uint8] flags:
+    READ,
+    WRITE;
+`
+	for _, style := range []string{"standard", "iota", "const"} {
+		t.Run(style, func(t *testing.T) {
+			files := generate(t, src, map[string]string{"prefix_enum_name": "true", "enum_style": style})
+			typeCheck(t, files)
+
+			out := body(files)
+			for _, want := range []string{"ColorDarkBlue", "ColorLightGreen", "PermRead", `"READ"`} {
+				if !strings.Contains(out, want) {
+					t.Errorf("expected generated code to contain %q", want)
+				}
+			}
+			// The EDL name is only kept as a string, e.g. in ColorNames.
+			if strings.Contains(out, "\tDARK_BLUE") {
+				t.Errorf("expected member identifiers to be converted, got:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestGenerateWithoutMaps(t *testing.T) {
+	flags := `
+enum Perm [uint8] flags:
+    READ,
+    WRITE;
+`
+	single := `
+enum Level [int]:
+    LOW = 1,
+    HIGH = 2;
+`
+	for style, src := range map[string]string{
+		"standard": sampleSource + flags,
+		"iota":     sampleSource + flags,
+		"const":    single + flags,
+	} {
+		t.Run(style, func(t *testing.T) {
+			files := generate(t, src, map[string]string{"generate_map": "false", "enum_style": style})
+			typeCheck(t, files)
+
+			out := body(files)
+			if strings.Contains(out, "KeyMap") || strings.Contains(out, "ValueMap") {
+				t.Errorf("expected no lookup maps, got:\n%s", out)
+			}
+			if !strings.Contains(out, "switch ") {
+				t.Errorf("expected switch-based lookups, got:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestGenerateIdentifierCollision(t *testing.T) {
+	codegen.Init()
+
+	path := filepath.Join(t.TempDir(), "enums.edl")
+	src := "enum Color [string]:\n    DARK_BLUE = \"a\",\n    darkBlue = \"b\";\n"
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatalf("failed to write source: %v", err)
+	}
+
+	if _, err := compiler.CompileFile(path, "", "go", false, map[string]string{"prefix_enum_name": "true"}); err == nil {
+		t.Fatal("expected an error for members mapping to the same identifier")
+	}
+}
+
+func TestGenerateSQL(t *testing.T) {
+	src := `
+enum Status [uint8]:
+    SUCCESS = 0,
+    ERROR = 200;
+
+enum Color [string]:
+    RED = "red",
+    GREEN = "green";
+
+enum Ratio [float64]:
+    HALF = 0.5;
+
+enum Perm [uint64] flags:
+    READ,
+    WRITE;
+`
+	for _, style := range []string{"standard", "iota", "const"} {
+		for _, column := range []string{"key", "value"} {
+			t.Run(style+"/"+column, func(t *testing.T) {
+				source := src
+				if style != "const" {
+					source += "enum Day [string, int64]:\n    MONDAY = \"Monday\":1,\n    TUESDAY = \"Tuesday\":2;\n"
+				}
+				files := generate(t, source, map[string]string{"generate_sql": "true", "sql_column": column, "enum_style": style})
+				typeCheck(t, files)
+
+				out := body(files)
+				for _, want := range []string{
+					"func (e *Color) Scan(src any) error",
+					"func (e Color) Value() (driver.Value, error)",
+					"func (e Color) RawValue() string",
+					"func (f *Perm) Scan(src any) error",
+					"case []byte:",
+					"case int64:",
+					"case float64:",
+				} {
+					if !strings.Contains(out, want) {
+						t.Errorf("expected generated code to contain %q", want)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestGenerateText(t *testing.T) {
+	src := `
+enum Status [uint8]:
+    SUCCESS = 0,
+    ERROR = 200;
+
+enum Color [string]:
+    RED = "red",
+    GREEN = "green";
+
+enum Grade [char]:
+    A = 'a',
+    B = 'b';
+
+enum Ratio [float32]:
+    HALF = 0.5;
+
+enum Enabled [bool]:
+    ON = true,
+    OFF = false;
+
+enum Perm [uint8] flags:
+    READ,
+    WRITE;
+`
+	for _, style := range []string{"standard", "iota", "const"} {
+		t.Run(style, func(t *testing.T) {
+			files := generate(t, src, map[string]string{"generate_text": "true", "enum_style": style})
+			typeCheck(t, files)
+
+			out := body(files)
+			for _, want := range []string{
+				"func (e Color) MarshalText() ([]byte, error)",
+				"func (e Color) AppendText(b []byte) ([]byte, error)",
+				"func (e *Grade) UnmarshalText(text []byte) error",
+				"func (f *Perm) UnmarshalText(text []byte) error",
+				"strconv.ParseUint(s, 10, 8)",
+				"strconv.ParseFloat(s, 32)",
+				"return e.UnmarshalText(data)",
+			} {
+				if !strings.Contains(out, want) {
+					t.Errorf("expected generated code to contain %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateYAML(t *testing.T) {
+	src := `
+enum Status [int]:
+    SUCCESS = 0,
+    ERROR = 2;
+
+enum Grade [char]:
+    A = 'a',
+    B = 'b';
+
+enum Perm [uint8] flags:
+    READ,
+    WRITE;
+`
+	for _, style := range []string{"standard", "iota", "const"} {
+		t.Run(style, func(t *testing.T) {
+			files := generate(t, src, map[string]string{"generate_yaml": "true", "enum_style": style})
+			typeCheck(t, files)
+
+			out := body(files)
+			for _, want := range []string{
+				"func (e Status) MarshalYAML() (any, error)",
+				"func (e *Grade) UnmarshalYAML(value *yaml.Node) error",
+				`"one of: a, b"`,
+				`"a '|' separated list of: READ, WRITE"`,
+			} {
+				if !strings.Contains(out, want) {
+					t.Errorf("expected generated code to contain %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateFlagValue(t *testing.T) {
+	src := `
+enum Level [int]:
+    LOW = 1,
+    HIGH = 2;
+
+enum Color [string]:
+    RED = "red",
+    GREEN = "green";
+
+enum Perm [uint8] flags:
+    READ,
+    WRITE;
+`
+	for _, style := range []string{"standard", "iota", "const"} {
+		t.Run(style, func(t *testing.T) {
+			files := generate(t, src, map[string]string{"generate_flag": "true", "generate_stringer": "false", "enum_style": style})
+			typeCheck(t, files)
+
+			out := body(files)
+			for _, want := range []string{
+				"func (e *Color) Set(s string) error",
+				"func (e Color) Type() string",
+				"func (e Color) String() string",
+				"func ColorFlag(fs *flag.FlagSet, name string, def Color, usage string) *Color",
+				`usage+" (one of: red, green)"`,
+				"func (v PermFlagValue) Set(s string) error",
+				"strconv.ParseInt(s, 10, 0)",
+			} {
+				if !strings.Contains(out, want) {
+					t.Errorf("expected generated code to contain %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateIteration(t *testing.T) {
+	for _, style := range []string{"standard", "iota"} {
+		t.Run(style, func(t *testing.T) {
+			files := generate(t, sampleSource, map[string]string{"enum_style": style, "prefix_enum_name": "true"})
+			typeCheck(t, files)
+
+			out := body(files)
+			for _, want := range []string{
+				"var _ColorMembers = [...]Color{\n\tColorRed,\n\tColorGreen,\n\tColorBlue,\n}",
+				"func ColorValues() []Color {",
+				"return []string{\n\t\t\"RED\",\n\t\t\"GREEN\",\n\t\t\"BLUE\",\n\t}",
+				"func AllColor() iter.Seq[Color] {",
+			} {
+				if !strings.Contains(out, want) {
+					t.Errorf("expected generated code to contain %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateAliases(t *testing.T) {
+	src := `
+enum Color [string]:
+    RED = "red" alias "crimson", "scarlet",
+    GREEN = "green" alias "lime";
+
+enum Perm [uint8] flags:
+    READ alias "r",
+    WRITE;
+`
+	for _, style := range []string{"standard", "iota", "const"} {
+		for _, maps := range []string{"true", "false"} {
+			t.Run(style+"/maps="+maps, func(t *testing.T) {
+				files := generate(t, src, map[string]string{
+					"enum_style":       style,
+					"generate_map":     maps,
+					"case_insensitive": "true",
+					"trim_space":       "true",
+				})
+				typeCheck(t, files)
+
+				out := body(files)
+				for _, want := range []string{
+					"func _lookupColorKey(key string) (m Color, ok bool)",
+					"key = strings.TrimSpace(key)",
+					"key = strings.ToLower(key)",
+					`"crimson"`,
+					`"scarlet"`,
+					`"read"`,
+					`"r"`,
+				} {
+					if !strings.Contains(out, want) {
+						t.Errorf("expected generated code to contain %q", want)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestGenerateCaseInsensitiveCollision(t *testing.T) {
+	codegen.Init()
+
+	path := filepath.Join(t.TempDir(), "enums.edl")
+	src := "enum Color [string]:\n    RED = \"red\",\n    LOUD_RED = \"RED\";\n"
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatalf("failed to write source: %v", err)
+	}
+
+	if _, err := compiler.CompileFile(path, "", "go", false, map[string]string{"case_insensitive": "true"}); err == nil {
+		t.Fatal("expected an error for keys that only differ in case")
+	}
+}
+
+func TestGenerateDefaultMember(t *testing.T) {
+	src := `
+enum Color [string]:
+    RED = "red",
+    @default UNKNOWN = "unknown";
+
+enum Status [int]:
+    OK = 200,
+    @default OTHER = 0;
+`
+	for _, style := range []string{"standard", "iota", "const"} {
+		for _, mode := range []string{"error", "default", "preserve"} {
+			for _, text := range []string{"false", "true"} {
+				t.Run(style+"/"+mode+"/text="+text, func(t *testing.T) {
+					files := generate(t, src, map[string]string{
+						"enum_style":    style,
+						"json_unknown":  mode,
+						"generate_text": text,
+					})
+					typeCheck(t, files)
+
+					out := body(files)
+					for _, want := range []string{
+						"func ParseColorOrDefault(key string) Color {",
+						"func ParseStatusOrDefault(key int) Status {",
+					} {
+						if !strings.Contains(out, want) {
+							t.Errorf("expected generated code to contain %q", want)
+						}
+					}
+
+					fallback := strings.Contains(out, "*e = UNKNOWN")
+					preserve := strings.Contains(out, "*e = Color{key: k}") || strings.Contains(out, "*e = Color(k)")
+					switch {
+					case mode == "error" && (fallback || preserve):
+						t.Error("expected unknown keys to be rejected")
+					case mode == "default" && !fallback:
+						t.Error("expected unknown keys to decode as the default member")
+					case mode == "preserve" && style != "iota" && !preserve:
+						t.Error("expected unknown keys to be preserved")
+					case mode == "preserve" && style == "iota" && !fallback:
+						t.Error("expected iota enums to fall back to the default member")
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestGenerateInvalidDefaultMembers(t *testing.T) {
+	codegen.Init()
+
+	for name, tc := range map[string]struct {
+		src     string
+		options map[string]string
+	}{
+		"duplicate": {src: "enum Color [string]:\n    @default RED = \"red\",\n    @default BLUE = \"blue\";\n"},
+		"flags":     {src: "enum Perm [uint8] flags:\n    @default READ,\n    WRITE;\n"},
+		"mode":      {src: "enum Color [string]:\n    @default RED = \"red\";\n", options: map[string]string{"json_unknown": "bogus"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "enums.edl")
+			if err := os.WriteFile(path, []byte(tc.src), 0644); err != nil {
+				t.Fatalf("failed to write source: %v", err)
+			}
+
+			ctx, err := compiler.CompileFile(path, "", "go", false, tc.options)
+			if err == nil && !ctx.Validations.HasErrors() {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestGenerateAnnotations(t *testing.T) {
+	src := `
+@deprecated("use Colour")
+enum Color [string]:
+    RED = "red",
+    @label("Dark Red") @json("dark_red")
+    DARK_RED = "darkred",
+    @deprecated("use RED")
+    CRIMSON = "crimson";
+
+enum Perm [uint8] flags:
+    READ,
+    @deprecated
+    WRITE;
+`
+	for _, style := range []string{"standard", "iota", "const"} {
+		for _, text := range []string{"false", "true"} {
+			t.Run(style+"/text="+text, func(t *testing.T) {
+				files := generate(t, src, map[string]string{
+					"enum_style":    style,
+					"generate_text": text,
+				})
+				typeCheck(t, files)
+
+				out := body(files)
+				for _, want := range []string{
+					"// Deprecated: use Colour\n//\n//enumgen:enum\ntype Color",
+					"\t//\n\t// Deprecated: use RED\n\tCRIMSON",
+					"\t// Deprecated: WRITE should no longer be used.\n\tWRITE",
+					"func (e Color) Label() string {",
+					"case DARK_RED:\n\t\treturn \"Dark Red\"",
+					"\tdefault:\n\t\treturn fmt.Sprint(e.Key())\n\t}\n}",
+					"func _ColorJSONName(key string) string {",
+					"k = _ColorJSONKey(k)",
+				} {
+					if !strings.Contains(out, want) {
+						t.Errorf("expected generated code to contain %q", want)
+					}
+				}
+				if strings.Contains(out, "func (f Perm) Label()") {
+					t.Error("expected no Label method for an enum without labels")
+				}
+			})
+		}
+	}
+}
+
+func TestGenerateInvalidAnnotations(t *testing.T) {
+	codegen.Init()
+
+	for name, src := range map[string]string{
+		"unknown":       "enum Color [string]:\n    @shiny RED = \"red\";\n",
+		"enum label":    "@label(\"Colors\")\nenum Color [string]:\n    RED = \"red\";\n",
+		"missing arg":   "enum Color [string]:\n    @label RED = \"red\";\n",
+		"non-string":    "enum Color [string]:\n    @label(1) RED = \"red\";\n",
+		"repeated":      "enum Color [string]:\n    @label(\"a\") @label(\"b\") RED = \"red\";\n",
+		"json int key":  "enum Status [int]:\n    @json(\"ok\") OK = 200;\n",
+		"json on flags": "enum Perm [uint8] flags:\n    @json(\"r\") READ;\n",
+		"json clash":    "enum Color [string]:\n    @json(\"blue\") RED = \"red\",\n    BLUE = \"blue\";\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "enums.edl")
+			if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+				t.Fatalf("failed to write source: %v", err)
+			}
+
+			ctx, err := compiler.CompileFile(path, "", "go", false, nil)
+			if err == nil && !ctx.Validations.HasErrors() && !ctx.Errors.HasErrors() {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestGenerateTuples(t *testing.T) {
+	src := `
+enum HTTPStatus [code int unique, text string, retryable bool, weight float64]:
+    OK = (200, "OK", false, 1.5),
+    @label("Throttled") TOO_MANY = (429, "Too Many Requests", true, 0),
+    @default UNAVAILABLE = (503, "Service Unavailable", true, -2);
+`
+	for _, style := range []string{"standard", "iota", "const"} {
+		for _, maps := range []string{"true", "false"} {
+			t.Run(style+"/generate_map="+maps, func(t *testing.T) {
+				files := generate(t, src, map[string]string{
+					"enum_style":    style,
+					"generate_map":  maps,
+					"generate_json": "true",
+					"generate_text": "true",
+					"generate_sql":  "true",
+					"json_unknown":  "preserve",
+				})
+				typeCheck(t, files)
+
+				out := body(files)
+				for _, want := range []string{
+					"type HTTPStatus struct {\n\tkey       string\n\tcode      int\n",
+					"\t\tcode:      429,\n\t\ttext:      \"Too Many Requests\",\n\t\tretryable: true,\n",
+					"func (e HTTPStatus) Code() int {",
+					"func (e HTTPStatus) Retryable() bool {",
+					"func (e HTTPStatus) Weight() float64 {",
+					"func ParseHTTPStatusByCode(v int) (HTTPStatus, error) {",
+				} {
+					if !strings.Contains(out, want) {
+						t.Errorf("expected generated code to contain %q", want)
+					}
+				}
+				for _, unwanted := range []string{"ParseHTTPStatusValue", "ParseHTTPStatusByText", "RawValue"} {
+					if strings.Contains(out, unwanted) {
+						t.Errorf("expected generated code not to contain %q", unwanted)
+					}
+				}
+				if hasMap := strings.Contains(out, "HTTPStatusCodeMap = map[int]HTTPStatus{"); hasMap != (maps == "true") {
+					t.Errorf("expected the code lookup map only with generate_map, got %v", hasMap)
+				}
+			})
+		}
+	}
+}
+
+func TestGenerateTupleStyleWarning(t *testing.T) {
+	codegen.Init()
+
+	path := filepath.Join(t.TempDir(), "enums.edl")
+	src := "enum HTTPStatus [code int, text string]:\n    OK = (200, \"OK\");\n"
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatalf("failed to write source: %v", err)
+	}
+
+	for style, want := range map[string]string{
+		"iota":     "tuple enum HTTPStatus cannot use enum style 'iota' and is generated in the 'standard' style",
+		"const":    "tuple enum HTTPStatus cannot use enum style 'const' and is generated in the 'standard' style",
+		"standard": "",
+	} {
+		t.Run(style, func(t *testing.T) {
+			ctx, err := compiler.CompileFile(path, "", "go", false, map[string]string{"enum_style": style})
+			if err != nil || ctx.Validations.HasErrors() {
+				t.Fatalf("unexpected errors: %v\n%s", err, ctx.Validations.String())
+			}
+
+			warnings := ctx.Validations.FormatWarnings()
+			if want == "" && ctx.Validations.HasWarnings() {
+				t.Errorf("expected no warnings, got:\n%s", warnings)
+			}
+			if want != "" && !strings.Contains(warnings, want) {
+				t.Errorf("expected warning %q, got:\n%s", want, warnings)
+			}
+		})
+	}
+}
+
+func TestGenerateInvalidTuples(t *testing.T) {
+	codegen.Init()
+
+	for name, tc := range map[string]struct {
+		src     string
+		options map[string]string
+	}{
+		"arity":        {src: "enum S [code int, text string]:\n    OK = (200);\n"},
+		"field type":   {src: "enum S [code int, text string]:\n    OK = (\"200\", \"OK\");\n"},
+		"not a tuple":  {src: "enum S [code int, text string]:\n    OK = 200;\n"},
+		"no value":     {src: "enum S [code int, text string]:\n    OK;\n"},
+		"unique":       {src: "enum S [code int unique]:\n    OK = (200),\n    FINE = (200);\n"},
+		"duplicate":    {src: "enum S [code int, code string]:\n    OK = (200, \"OK\");\n"},
+		"unknown type": {src: "enum S [code integer]:\n    OK = (200);\n"},
+		"mixed":        {src: "enum S [code int, string]:\n    OK = (200, \"OK\");\n"},
+		"no fields":    {src: "enum S [int]:\n    OK = (200);\n"},
+		"flags":        {src: "enum S [bit uint8] flags:\n    READ = (1);\n"},
+		"reserved":     {src: "enum S [key string]:\n    OK = (\"ok\");\n"},
+		"sql value":    {src: "enum S [code int]:\n    OK = (200);\n", options: map[string]string{"generate_sql": "true", "sql_column": "value"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "enums.edl")
+			if err := os.WriteFile(path, []byte(tc.src), 0644); err != nil {
+				t.Fatalf("failed to write source: %v", err)
+			}
+
+			ctx, err := compiler.CompileFile(path, "", "go", false, tc.options)
+			if err == nil && !ctx.Validations.HasErrors() && !ctx.Errors.HasErrors() {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestGenerateReferences(t *testing.T) {
+	src := `
+enum Status [int]:
+    OK = 0,
+    ERROR = 1,
+    @deprecated FAILURE = ERROR,
+    BROKEN = Status.FAILURE;
+
+enum Level [int]:
+    LOW = Status.ERROR,
+    HIGH = 2;
+
+enum HTTPStatus [code int unique, status Status unique, level int]:
+    SUCCESS = (200, Status.OK, Level.LOW),
+    UNAVAILABLE = (503, Status.BROKEN, 3);
+
+enum Perm [uint8] flags:
+    READ,
+    RO = READ,
+    WRITE;
+`
+	for _, style := range []string{"standard", "iota", "const"} {
+		t.Run(style, func(t *testing.T) {
+			files := generate(t, src, map[string]string{
+				"enum_style":    style,
+				"generate_json": "true",
+			})
+			typeCheck(t, files)
+
+			out := body(files)
+			for _, want := range []string{
+				"// Member aliases\n",
+				"\t//\n\t// Deprecated: FAILURE should no longer be used.\n\tFAILURE = ERROR\n",
+				"\t// BROKEN is an alias of FAILURE.\n\tBROKEN = FAILURE\n",
+				"\tRO = READ\n",
+				"\tstatus Status\n",
+				"\t\tstatus: BROKEN,\n\t\tlevel:  3,\n",
+				"\t\tstatus: OK,\n\t\tlevel:  1,\n",
+				"func (e HTTPStatus) Status() Status {",
+				"func ParseHTTPStatusByStatus(v Status) (HTTPStatus, error) {",
+				"const _PermMask = READ | WRITE\n",
+			} {
+				if !strings.Contains(out, want) {
+					t.Errorf("expected generated code to contain %q", want)
+				}
+			}
+			if strings.Contains(out, "\"FAILURE\",") {
+				t.Error("expected aliases not to be listed as members")
+			}
+		})
+	}
+}
+
+func TestGenerateInvalidReferences(t *testing.T) {
+	codegen.Init()
+
+	for name, src := range map[string]string{
+		"undefined member": "enum S [int]:\n    A = NOPE;\n",
+		"undefined enum":   "enum S [int]:\n    A = Other.X;\n",
+		"cycle":            "enum S [int]:\n    A = B,\n    B = C,\n    C = A;\n",
+		"type mismatch":    "enum S [int]:\n    A = 1;\nenum T [string]:\n    X = S.A;\n",
+		"no value":         "enum S:\n    A;\nenum T [string]:\n    X = S.A;\n",
+		"duplicate value":  "enum S [int]:\n    A = 1;\nenum T [int]:\n    X = S.A,\n    Y = 1;\n",
+		"alias label":      "enum S [int]:\n    A = 1,\n    @label(\"a\") B = A;\n",
+		"alias aliases":    "enum S [string]:\n    A = \"a\",\n    B = A alias \"b\";\n",
+		"wrong enum":       "enum S [int]:\n    A = 1;\nenum T [int]:\n    B = 1;\nenum H [s S]:\n    X = (T.B);\n",
+		"literal for enum": "enum S [int]:\n    A = 1;\nenum H [s S]:\n    X = (1);\n",
+		"own type":         "enum N [next N]:\n    A = (N.A);\n",
+		"mutual types":     "enum A [b B]:\n    X = (B.Y);\nenum B [a A]:\n    Y = (A.X);\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "enums.edl")
+			if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+				t.Fatalf("failed to write source: %v", err)
+			}
+
+			ctx, err := compiler.CompileFile(path, "", "go", false, nil)
+			if err == nil && !ctx.Validations.HasErrors() && !ctx.Errors.HasErrors() {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
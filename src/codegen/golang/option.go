@@ -7,6 +7,16 @@ const (
 	OptionPrefixEnumName   = "prefix_enum_name"
 	OptionGenerateMap      = "generate_map"
 	OptionEnumStyle        = "enum_style"
+	OptionCaseInsensitive  = "case_insensitive"
+	OptionTrimSpace        = "trim_space"
+	OptionOutputMode       = "output_mode"
+	OptionFileName         = "file_name"
+	OptionGenerateText     = "generate_text"
+	OptionGenerateYAML     = "generate_yaml"
+	OptionGenerateFlag     = "generate_flag"
+	OptionGenerateSQL      = "generate_sql"
+	OptionSQLColumn        = "sql_column"
+	OptionJSONUnknown      = "json_unknown"
 )
 
 type OptionDef struct {
@@ -34,17 +44,67 @@ var allOptions = []OptionDef{
 	{
 		Key:          OptionPrefixEnumName,
 		DefaultValue: "false",
-		HelpText:     "If true, prefixes member names with the enum type name (e.g., ColorRED).",
+		HelpText:     "If true, prefixes member names with the enum type name and converts them to PascalCase (e.g., ColorDarkBlue).",
 	},
 	{
 		Key:          OptionGenerateMap,
 		DefaultValue: "true",
-		HelpText:     "If true, generates a map of all enum members.",
+		HelpText:     "If true, generates key and value lookup maps; otherwise lookups use switch statements.",
+	},
+	{
+		Key:          OptionGenerateText,
+		DefaultValue: "false",
+		HelpText:     "If true, generates MarshalText, UnmarshalText and AppendText methods; JSON methods then delegate to them.",
+	},
+	{
+		Key:          OptionGenerateYAML,
+		DefaultValue: "false",
+		HelpText:     "If true, generates MarshalYAML and UnmarshalYAML methods for gopkg.in/yaml.v3.",
+	},
+	{
+		Key:          OptionGenerateFlag,
+		DefaultValue: "false",
+		HelpText:     "If true, generates flag.Value and pflag.Value methods and an <Enum>Flag helper; implies generate_stringer.",
+	},
+	{
+		Key:          OptionGenerateSQL,
+		DefaultValue: "false",
+		HelpText:     "If true, generates sql.Scanner and driver.Valuer methods; the value accessor is then named RawValue.",
+	},
+	{
+		Key:          OptionSQLColumn,
+		DefaultValue: "key",
+		HelpText:     "Whether enums are stored in the database by their 'key' or their 'value'.",
+	},
+	{
+		Key:          OptionJSONUnknown,
+		DefaultValue: "error",
+		HelpText:     "How UnmarshalJSON handles unknown keys: 'error', 'default' to decode them as the enum's @default member, or 'preserve' to keep unknown string keys for round-tripping.",
 	},
 	{
 		Key:          OptionEnumStyle,
 		DefaultValue: "standard",
-		HelpText:     "The style of the generated enum code ('standard', 'iota', etc.).",
+		HelpText:     "The style of the generated enum code ('standard', 'iota' or 'const').",
+	},
+	{
+		Key:          OptionCaseInsensitive,
+		DefaultValue: "false",
+		HelpText:     "If true, Parse<Enum>Key matches string keys and aliases case-insensitively.",
+	},
+	{
+		Key:          OptionTrimSpace,
+		DefaultValue: "false",
+		HelpText:     "If true, Parse<Enum>Key ignores whitespace around string keys.",
+	},
+	{
+		Key:          OptionOutputMode,
+		DefaultValue: "per_enum",
+		HelpText:     "Whether to write one file per enum ('per_enum') or one file per EDL source ('single').",
+	},
+	{
+		Key:          OptionFileName,
+		DefaultValue: "",
+		HelpText:     "The generated file name pattern; {enum} is the lower-cased enum name and {file} the EDL file name (default: '{enum}_gen.go', or '{file}_gen.go' in single mode).",
 	},
 }
 
@@ -0,0 +1,31 @@
+package golang
+
+import (
+	"fmt"
+
+	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
+)
+
+// goExpr is Go source that templates print verbatim with %#v, such as the
+// identifier of a referenced enum member.
+type goExpr string
+
+func (e goExpr) GoString() string { return string(e) }
+
+// referenceFormatter formats references to the members of an enum as the Go
+// identifiers of those members.
+type referenceFormatter struct {
+	enum   string
+	prefix bool
+}
+
+func (r *referenceFormatter) GoTypeName() string { return r.enum }
+func (r *referenceFormatter) ZeroValue() any     { return goExpr("*new(" + r.enum + ")") }
+
+func (r *referenceFormatter) FormatMemberValue(irValue compiler.IRValue, memberName string, index int) (any, error) {
+	ref, ok := irValue.(compiler.IRReference)
+	if !ok || ref.Enum() != r.enum {
+		return nil, fmt.Errorf("member '%s' must reference a member of '%s', got %v", memberName, r.enum, irValue)
+	}
+	return goExpr(memberIdent(ref.Enum(), ref.Member(), r.prefix)), nil
+}
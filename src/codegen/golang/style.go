@@ -6,6 +6,16 @@ const (
 	// StyleStandard generates a standard Go enum with const values of a custom type
 	StyleStandard Style = "standard"
 
+	// StyleIota generates an integer-backed enum whose members are declared with iota
+	StyleIota Style = "iota"
+
+	// StyleConst generates a named type over the single declared value type with typed constant members
+	StyleConst Style = "const"
+
+	// StyleFlags generates a bit set over an integer type. It is selected for enums
+	// declared with the 'flags' modifier regardless of the requested style.
+	StyleFlags Style = "flags"
+
 	// StyleUnknown is used for unrecognized styles
 	StyleUnknown Style = "unknown"
 )
@@ -18,6 +28,10 @@ func ParseStyle(style string) Style {
 	switch style {
 	case string(StyleStandard):
 		return StyleStandard
+	case string(StyleIota):
+		return StyleIota
+	case string(StyleConst):
+		return StyleConst
 	default:
 		return StyleUnknown
 	}
@@ -3,39 +3,173 @@ package golang
 import (
 	"embed"
 	"fmt"
+	"strings"
 	"text/template"
 )
 
-//go:embed templates/*.tmpl
+//go:embed templates
 var templatesFS embed.FS
 
+// partialTemplates are shared by all styles and define named templates, such
+// as "sql", which the style templates include.
+const partialTemplates = "templates/partials/*.tmpl"
+
 var defaultTemplates = map[Style]string{
 	StyleStandard: "templates/standard.go.tmpl",
-	StyleUnknown:  "templates/standard.go.tmpl",
+	StyleIota:     "templates/iota.go.tmpl",
+	StyleConst:    "templates/const.go.tmpl",
+	StyleFlags:    "templates/flags.go.tmpl",
+}
+
+// fileTemplate wraps the rendered enums with the header, package clause and
+// imports, so these are emitted once per generated file.
+const fileTemplate = "templates/file.go.tmpl"
+
+// Directives are emitted into generated code so that tools such as the
+// exhaustive analyzer can recognize enum types and their members. The enum
+// directive is part of the type's doc comment and the members directive is part
+// of the doc comment of the declaration holding the members.
+const (
+	EnumDirective    = "//enumgen:enum"
+	MembersDirective = "//enumgen:members"
+)
+
+var templateFuncs = template.FuncMap{
+	"comment":          comment,
+	"lineComment":      lineComment,
+	"lower":            strings.ToLower,
+	"enumDirective":    func() string { return EnumDirective },
+	"membersDirective": func() string { return MembersDirective },
+}
+
+// comment renders text as Go line comments. Every line after the first is
+// prefixed with indent so the block lines up with the first line.
+func comment(indent, text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = "//"
+		} else {
+			lines[i] = "// " + line
+		}
+		if i > 0 {
+			lines[i] = indent + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// lineComment renders text as a Go comment trailing a declaration on the same
+// line, or nothing when text is empty.
+func lineComment(text string) string {
+	if text == "" {
+		return ""
+	}
+	return " // " + strings.Join(strings.Fields(text), " ")
+}
+
+// Annotation is an EDL annotation such as @label("Dark Red") with its unquoted arguments.
+type Annotation struct {
+	Name string
+	Args []string
 }
 
 type TemplateMember struct {
-	Name  string
-	Doc   string
-	Key   any
-	Value any
+	Name        string
+	Ident       string
+	Doc         string
+	Comment     string // trailing comment kept beside Doc, if any
+	Index       int
+	Key         any
+	Value       any
+	Lookups     []any // keys accepted when parsing, including aliases
+	Annotations []Annotation
+	Deprecated  string // deprecation notice from @deprecated, if any
+	Label       string // label from @label, if any
+	JSONName    string // JSON name from @json, if any
+	Fields      []any  // values of the fields of a tuple member, in field order
+}
+
+// TemplateMemberAlias is a member assigned another member of its enum, which
+// becomes a second Go identifier for that member.
+type TemplateMemberAlias struct {
+	Name       string
+	Ident      string
+	Target     string // Go identifier of the aliased member
+	Doc        string
+	Comment    string
+	Deprecated string
+}
+
+// TemplateField is a named field of a tuple enum.
+type TemplateField struct {
+	Name     string // field name in EDL
+	Ident    string // unexported struct field holding the value
+	Accessor string // exported method returning the value
+	Type     string // Go type of the field
+	Unique   bool   // whether members can be parsed from the field's value
+}
+
+// FileData holds everything that appears once per generated file. Enums are
+// the already rendered bodies of the enum templates.
+type FileData struct {
+	EDLVersion  string
+	ToolVersion string
+	Package     string
+	PackageDoc  string
+	Enums       []string
+}
+
+// Encoding describes the representation of an enum used by an encoding, such
+// as its text form or its database column.
+type Encoding struct {
+	Source   string // "key" or "value"
+	Type     string // Go type of the encoded representation
+	Kind     string // "string", "int", "uint", "float" or "bool"
+	Bits     int    // bit size of Type for strconv, 0 for int and uint
+	Parse    string // function parsing the representation into the enum
+	Accessor string // method returning the representation of the enum
 }
 
 type TemplateData struct {
-	EDLVersion       string
-	ToolVersion      string
-	Package          string
 	EnumName         string
+	IsFlags          bool
+	Receiver         string
+	ValueMethod      string
 	EnumDoc          string
+	EnumDeprecated   string
+	Annotations      []Annotation
+	UnderlyingType   string
 	KeyType          string
 	ValueType        string
 	KeyZeroValue     any
 	ValueZeroValue   any
 	Members          []TemplateMember
+	MemberAliases    []TemplateMemberAlias
+	MemberDecl       string // "var" or "const", the keyword declaring the members
+	Fields           []TemplateField
+	UniqueFields     bool
 	GenerateStringer bool
 	GenerateJSON     bool
 	PrefixEnumName   bool
 	GenerateMap      bool
+	KeyTable         string
+	KeyLookup        bool
+	HasAliases       bool
+	CaseInsensitive  bool
+	TrimSpace        bool
+	DefaultMember    string // identifier of the @default member, if any
+	UnknownKey       string // expression UnmarshalJSON assigns for an unknown key k, if any
+	PreserveUnknown  bool   // whether UnknownKey keeps the unknown key
+	HasLabels        bool
+	JSONNames        bool
+	GenerateText     bool
+	GenerateYAML     bool
+	GenerateFlag     bool
+	GenerateSQL      bool
+	ValidKeys        string
+	Text             Encoding
+	SQL              Encoding
 }
 
 // LoadTemplates loads the Go templates from the embedded filesystem
@@ -44,14 +178,12 @@ func LoadTemplates(templates map[Style]string, fs embed.FS) (map[Style]*template
 	loadedTemplates := make(map[Style]*template.Template)
 
 	for style, path := range templates {
-		data, err := fs.ReadFile(path)
+		tmpl, err := loadTemplate(style.String(), path, fs)
 		if err != nil {
 			return nil, err
 		}
-
-		tmpl, err := template.New(style.String()).Parse(string(data))
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse template %s: %w", path, err)
+		if tmpl, err = tmpl.ParseFS(fs, partialTemplates); err != nil {
+			return nil, fmt.Errorf("failed to parse partial templates: %w", err)
 		}
 
 		loadedTemplates[style] = tmpl
@@ -59,3 +191,17 @@ func LoadTemplates(templates map[Style]string, fs embed.FS) (map[Style]*template
 
 	return loadedTemplates, nil
 }
+
+func loadTemplate(name, path string, fs embed.FS) (*template.Template, error) {
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", path, err)
+	}
+
+	return tmpl, nil
+}
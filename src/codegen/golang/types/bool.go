@@ -1,10 +1,8 @@
 package types
 
 import (
-	"fmt"
-
+	"github.com/kkumar-gcc/enumgen/src/codegen/primitive"
 	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
-	"github.com/kkumar-gcc/enumgen/src/token"
 )
 
 type BoolFormatter struct{}
@@ -13,27 +11,5 @@ func (r *BoolFormatter) GoTypeName() string { return "bool" }
 func (r *BoolFormatter) ZeroValue() any     { return false }
 
 func (r *BoolFormatter) FormatMemberValue(irValue compiler.IRValue, memberName string, index int) (any, error) {
-	if irValue == nil {
-		return false, nil
-	}
-
-	literal, ok := irValue.(compiler.IRLiteral)
-	if !ok {
-		return nil, fmt.Errorf("internal error: expected IRLiteral for bool handler, got %T", irValue)
-	}
-
-	switch kind := literal.Kind(); kind {
-	case token.TRUE:
-		return true, nil
-	case token.FALSE:
-		return false, nil
-	default:
-		pos := literal.Position()
-		return nil, fmt.Errorf(
-			"type error at %s member '%s' expects a boolean (true or false), but got %v",
-			pos.String(),
-			memberName,
-			kind,
-		)
-	}
+	return primitive.MustLookup("bool").Decode(irValue, memberName, index)
 }
@@ -0,0 +1,35 @@
+package golang
+
+type UnknownMode string
+
+const (
+	// UnknownError makes UnmarshalJSON fail on keys that match no member
+	UnknownError UnknownMode = "error"
+
+	// UnknownDefault decodes unknown keys as the enum's @default member
+	UnknownDefault UnknownMode = "default"
+
+	// UnknownPreserve keeps unknown string keys so they marshal back unchanged.
+	// Enums that cannot hold them fall back to UnknownDefault.
+	UnknownPreserve UnknownMode = "preserve"
+
+	// UnknownInvalid is used for unrecognized modes
+	UnknownInvalid UnknownMode = "invalid"
+)
+
+func (m UnknownMode) String() string {
+	return string(m)
+}
+
+func ParseUnknownMode(mode string) UnknownMode {
+	switch mode {
+	case string(UnknownError):
+		return UnknownError
+	case string(UnknownDefault):
+		return UnknownDefault
+	case string(UnknownPreserve):
+		return UnknownPreserve
+	default:
+		return UnknownInvalid
+	}
+}
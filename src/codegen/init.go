@@ -4,6 +4,12 @@ import (
 	"sync"
 
 	"github.com/kkumar-gcc/enumgen/src/codegen/golang"
+	"github.com/kkumar-gcc/enumgen/src/codegen/java"
+	"github.com/kkumar-gcc/enumgen/src/codegen/kotlin"
+	"github.com/kkumar-gcc/enumgen/src/codegen/proto"
+	"github.com/kkumar-gcc/enumgen/src/codegen/python"
+	"github.com/kkumar-gcc/enumgen/src/codegen/rust"
+	"github.com/kkumar-gcc/enumgen/src/codegen/typescript"
 )
 
 var (
@@ -20,5 +26,41 @@ func Init() {
 			panic("failed to initialize Go generator: " + err.Error())
 		}
 		DefaultRegistry.Register(goGenerator)
+
+		tsGenerator, err := typescript.New()
+		if err != nil {
+			panic("failed to initialize TypeScript generator: " + err.Error())
+		}
+		DefaultRegistry.Register(tsGenerator)
+
+		pyGenerator, err := python.New()
+		if err != nil {
+			panic("failed to initialize Python generator: " + err.Error())
+		}
+		DefaultRegistry.Register(pyGenerator)
+
+		rsGenerator, err := rust.New()
+		if err != nil {
+			panic("failed to initialize Rust generator: " + err.Error())
+		}
+		DefaultRegistry.Register(rsGenerator)
+
+		javaGenerator, err := java.New()
+		if err != nil {
+			panic("failed to initialize Java generator: " + err.Error())
+		}
+		DefaultRegistry.Register(javaGenerator)
+
+		ktGenerator, err := kotlin.New()
+		if err != nil {
+			panic("failed to initialize Kotlin generator: " + err.Error())
+		}
+		DefaultRegistry.Register(ktGenerator)
+
+		protoGenerator, err := proto.New()
+		if err != nil {
+			panic("failed to initialize Protocol Buffers generator: " + err.Error())
+		}
+		DefaultRegistry.Register(protoGenerator)
 	})
 }
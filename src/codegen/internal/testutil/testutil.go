@@ -0,0 +1,166 @@
+// Package testutil holds what the tests of the generators share: compiling
+// EDL sources with a generator, checking the generated code and running the
+// compilers of the target languages over it.
+package testutil
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kkumar-gcc/enumgen/src/codegen"
+	"github.com/kkumar-gcc/enumgen/src/compiler"
+	contracts "github.com/kkumar-gcc/enumgen/src/contracts/compiler"
+)
+
+// SampleSource declares the enums every generator is tested with: a numeric
+// enum with a member alias, a string enum with a key alias and a deprecated
+// member, a key-value enum and a flags enum. Tests append the tuple enums and
+// the edge cases of their language.
+const SampleSource = `// Enums shared with the services.
+
+// Status of an operation.
+enum Status [int]:
+    SUCCESS = 1,
+    WARNING = 2,
+    ERROR = 3,
+    FAILURE = ERROR;
+
+enum Color [string]:
+    RED = "red" alias "crimson",
+    @deprecated("use RED")
+    GREEN = "green",
+    BLUE = "blue";
+
+enum Day [string, uint8]:
+    MONDAY = "Monday":1,
+    TUESDAY = "Tuesday":2;
+
+enum Perm [uint8] flags:
+    READ,
+    WRITE alias "W",
+    EXEC = 0x4;
+`
+
+// AliasSource declares what SampleSource leaves out: a flags enum with key
+// aliases and a member alias, and a tuple enum with several unique fields and
+// a member alias.
+const AliasSource = `
+enum Mode [uint32] flags:
+    READ alias "r",
+    WRITE alias "w",
+    EXEC,
+    RUN = EXEC;
+
+enum Point [x int unique, y int, label string unique]:
+    ORIGIN = (0, 0, "origin"),
+    UNIT = (1, 1, "unit"),
+    ZERO = ORIGIN;
+`
+
+// Compile compiles src, saved as enums.edl, with the generator for lang.
+func Compile(t *testing.T, lang string, src string, options map[string]string) (*contracts.Context, error) {
+	t.Helper()
+	codegen.Init()
+
+	path := filepath.Join(t.TempDir(), "enums.edl")
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatalf("failed to write source: %v", err)
+	}
+
+	return compiler.CompileFile(path, "", lang, false, options)
+}
+
+// Generate compiles src with the generator for lang and returns the generated
+// files by path, failing the test if src does not compile.
+func Generate(t *testing.T, lang string, src string, options map[string]string) map[string]string {
+	t.Helper()
+
+	ctx, err := Compile(t, lang, src, options)
+	if err != nil {
+		t.Fatalf("compilation failed: %v", err)
+	}
+	if ctx.Validations.HasErrors() {
+		t.Fatalf("validation failed:\n%s", ctx.Validations.String())
+	}
+
+	files := make(map[string]string, len(ctx.OutputFiles))
+	for _, file := range ctx.OutputFiles {
+		files[file.Path] = string(file.Body)
+	}
+	return files
+}
+
+// AssertContains reports the snippets code does not contain.
+func AssertContains(t *testing.T, code string, snippets ...string) {
+	t.Helper()
+	for _, s := range snippets {
+		if !strings.Contains(code, s) {
+			t.Errorf("generated code does not contain %q:\n%s", s, code)
+		}
+	}
+}
+
+// LookPath returns the path of the named tool, skipping the test if it is not
+// installed.
+func LookPath(t *testing.T, name string) string {
+	t.Helper()
+
+	path, err := exec.LookPath(name)
+	if err != nil {
+		t.Skipf("%s is not installed", name)
+	}
+	return path
+}
+
+// WriteFiles writes files, keyed by their path relative to dir, and returns
+// the paths they were written to.
+func WriteFiles(t *testing.T, dir string, files map[string]string) []string {
+	t.Helper()
+
+	paths := make([]string, 0, len(files))
+	for name, body := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// Run runs the named tool in dir, failing the test with its output if it
+// does not succeed.
+func Run(t *testing.T, dir string, name string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("%s failed: %v\n%s", filepath.Base(name), err, out)
+	}
+}
+
+// JacksonClasspath returns the classpath of the Jackson annotations the JVM
+// generators refer to, skipping the test if they cannot be found. The jar is
+// taken from $JACKSON_ANNOTATIONS_JAR or the local Maven repository.
+func JacksonClasspath(t *testing.T) string {
+	t.Helper()
+
+	if jar := os.Getenv("JACKSON_ANNOTATIONS_JAR"); jar != "" {
+		return jar
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		pattern := filepath.Join(home, ".m2", "repository", "com", "fasterxml", "jackson", "core", "jackson-annotations", "*", "jackson-annotations-*.jar")
+		if jars, _ := filepath.Glob(pattern); len(jars) > 0 {
+			return jars[len(jars)-1]
+		}
+	}
+	t.Skip("the Jackson annotations jar was not found; set JACKSON_ANNOTATIONS_JAR")
+	return ""
+}
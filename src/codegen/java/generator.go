@@ -0,0 +1,70 @@
+package java
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kkumar-gcc/enumgen/pkg/strcase"
+	"github.com/kkumar-gcc/enumgen/src/codegen/jvm"
+)
+
+// keywords are the reserved words and literals of Java, which cannot name
+// packages, enums, constants or fields.
+var keywords = map[string]bool{
+	"abstract": true, "assert": true, "boolean": true, "break": true, "byte": true,
+	"case": true, "catch": true, "char": true, "class": true, "const": true,
+	"continue": true, "default": true, "do": true, "double": true, "else": true,
+	"enum": true, "extends": true, "final": true, "finally": true, "float": true,
+	"for": true, "goto": true, "if": true, "implements": true, "import": true,
+	"instanceof": true, "int": true, "interface": true, "long": true, "native": true,
+	"new": true, "package": true, "private": true, "protected": true, "public": true,
+	"return": true, "short": true, "static": true, "strictfp": true, "super": true,
+	"switch": true, "synchronized": true, "this": true, "throw": true, "throws": true,
+	"transient": true, "try": true, "void": true, "volatile": true, "while": true,
+	"true": true, "false": true, "null": true, "_": true,
+}
+
+// reservedMethods are methods of generated enums, or inherited from
+// java.lang.Enum, that field accessors and lookups must not replace.
+var reservedMethods = map[string]bool{
+	"getKey": true, "getValue": true, "getClass": true, "getDeclaringClass": true,
+	"fromKey": true, "fromValue": true, "fromBits": true, "toBits": true,
+	"values": true, "valueOf": true,
+}
+
+func New() (*jvm.Generator, error) {
+	enum, err := loadTemplate("enum", enumTemplate, templatesFS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	return jvm.NewGenerator(jvm.Language{
+		Name:              "Java",
+		Code:              "java",
+		Version:           Version,
+		Extension:         ".java",
+		Keywords:          keywords,
+		ReservedAccessors: reservedMethods,
+		ReservedLookups:   reservedMethods,
+		Types:             defaultValueTypes(),
+		Accessor:          func(pascal, _ string) string { return "get" + pascal },
+		LookupMap:         func(field string) string { return "BY_" + strings.ToUpper(strcase.ToSnake(field)) },
+		Literal:           literal,
+		Escape:            escape,
+		Imports:           imports,
+	}, enum), nil
+}
+
+// imports returns the classes the enum described by data refers to, sorted.
+func imports(data *jvm.TemplateData) []string {
+	imports := []string{"java.util.HashMap", "java.util.Map"}
+	if data.IsFlags {
+		imports = append(imports, "java.util.EnumSet", "java.util.Set")
+	}
+	if data.Jackson {
+		imports = append(imports, "com.fasterxml.jackson.annotation.JsonCreator", "com.fasterxml.jackson.annotation.JsonValue")
+	}
+	slices.Sort(imports)
+	return imports
+}
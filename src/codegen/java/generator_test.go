@@ -0,0 +1,159 @@
+package java_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kkumar-gcc/enumgen/src/codegen/internal/testutil"
+)
+
+const sampleSource = testutil.SampleSource + `
+enum HTTPStatus [code int32 unique, text string, status Status, status_type string]:
+    OK = (200, "OK", Status.SUCCESS, "success"),
+    UNAVAILABLE = (503, "Service \"Unavailable\"", Status.FAILURE, "error");
+
+enum Ratio [float32]:
+    ONE = 1,
+    HALF = 0.5;
+`
+
+func TestGenerate(t *testing.T) {
+	files := testutil.Generate(t, "java", sampleSource, nil)
+	if len(files) != 6 {
+		t.Fatalf("expected a file per enum, got %v", files)
+	}
+
+	testutil.AssertContains(t, files["enums/Status.java"],
+		"package enums;\n\nimport java.util.HashMap;\nimport java.util.Map;\n\n/** Status of an operation. */\npublic enum Status {\n    SUCCESS(1L),\n",
+		"    ;\n\n    /** FAILURE is an alias of {@link #ERROR}. */\n    public static final Status FAILURE = ERROR;",
+		"    public static Status fromKey(long key) {",
+	)
+	testutil.AssertContains(t, files["enums/Color.java"],
+		"    /** @deprecated use RED */\n    @Deprecated\n    GREEN(\"green\"),",
+		"        BY_KEY.put(\"crimson\", RED);",
+		"\"invalid Color key \" + key + \", expected one of: red, green, blue\"",
+	)
+	testutil.AssertContains(t, files["enums/Day.java"],
+		"    MONDAY(\"Monday\", (short) 1),",
+		"    public short getValue() {",
+		"    public static Day fromValue(short value) {",
+	)
+	testutil.AssertContains(t, files["enums/HTTPStatus.java"],
+		"    UNAVAILABLE(503, \"Service \\\"Unavailable\\\"\", Status.FAILURE, \"error\"),",
+		"    private static final Map<Integer, HTTPStatus> BY_CODE = new HashMap<>();",
+		"    public String getStatusType() {",
+		"    public static HTTPStatus fromCode(int code) {",
+		"        return name();",
+	)
+	testutil.AssertContains(t, files["enums/Perm.java"],
+		"    EXEC((short) 4),",
+		"        BY_KEY.put(\"W\", WRITE);",
+		"    public static EnumSet<Perm> fromBits(long bits) {",
+		"    public static long toBits(Set<Perm> flags) {",
+	)
+	testutil.AssertContains(t, files["enums/Ratio.java"], "    HALF(0.5f),")
+
+	if strings.Contains(files["enums/HTTPStatus.java"], "fromText") {
+		t.Errorf("fields not marked unique should not have a lookup")
+	}
+	if strings.Contains(files["enums/Color.java"], "jackson") {
+		t.Errorf("Jackson annotations should only be generated on request")
+	}
+}
+
+func TestGenerateAliases(t *testing.T) {
+	files := testutil.Generate(t, "java", testutil.AliasSource, nil)
+
+	testutil.AssertContains(t, files["enums/Mode.java"],
+		"public enum Mode {\n    READ(1L),\n    WRITE(2L),\n    EXEC(4L),\n    ;\n",
+		"    /** RUN is an alias of {@link #EXEC}. */\n    public static final Mode RUN = EXEC;",
+		"            BY_VALUE.putIfAbsent(member.value, member);",
+		"        BY_KEY.put(\"r\", READ);\n        BY_KEY.put(\"w\", WRITE);\n",
+		"    public static Mode fromKey(String key) {",
+		"\"invalid Mode key \" + key + \", expected one of: READ, WRITE, EXEC\"",
+	)
+	testutil.AssertContains(t, files["enums/Point.java"],
+		"    ORIGIN(0L, 0L, \"origin\"),\n    UNIT(1L, 1L, \"unit\"),\n    ;\n",
+		"    /** ZERO is an alias of {@link #ORIGIN}. */\n    public static final Point ZERO = ORIGIN;",
+		"            BY_X.put(member.x, member);\n            BY_LABEL.put(member.label, member);\n",
+		"    public static Point fromX(long x) {",
+		"    public static Point fromLabel(String label) {",
+		"    public long getY() {",
+	)
+	if strings.Contains(files["enums/Point.java"], "fromY") {
+		t.Errorf("fields not marked unique should not have a lookup")
+	}
+}
+
+func TestGenerateCompiles(t *testing.T) {
+	javac := testutil.LookPath(t, "javac")
+	classpath := testutil.JacksonClasspath(t)
+
+	dir := t.TempDir()
+	files := testutil.Generate(t, "java", sampleSource+testutil.AliasSource, map[string]string{
+		"package":          "com.example.enums",
+		"generate_jackson": "true",
+	})
+	paths := testutil.WriteFiles(t, filepath.Join(dir, "src"), files)
+	args := append([]string{"-cp", classpath, "-d", filepath.Join(dir, "classes")}, paths...)
+	testutil.Run(t, dir, javac, args...)
+}
+
+func TestGenerateOptions(t *testing.T) {
+	files := testutil.Generate(t, "java", sampleSource, map[string]string{
+		"package":          "com.example.enums",
+		"generate_jackson": "true",
+	})
+
+	code, ok := files["com/example/enums/Color.java"]
+	if !ok {
+		t.Fatalf("expected files in the directory of the package, got %v", files)
+	}
+	testutil.AssertContains(t, code,
+		"package com.example.enums;\n\nimport com.fasterxml.jackson.annotation.JsonCreator;\nimport com.fasterxml.jackson.annotation.JsonValue;\n",
+		"    @JsonValue\n    public String getKey() {",
+		"    @JsonCreator\n    public static Color fromKey(String key) {",
+	)
+
+	files = testutil.Generate(t, "java", sampleSource, map[string]string{"output_path": "."})
+	if _, ok := files["Color.java"]; !ok {
+		t.Errorf("expected output_path to replace the directory of the package, got %v", files)
+	}
+}
+
+func TestGenerateInvalid(t *testing.T) {
+	tests := map[string]string{
+		"keyword member": `
+enum Modifier [string]:
+    final = "final";
+`,
+		"reserved member": `
+enum Lookup [string]:
+    BY_KEY = "key";
+`,
+		"keyword field": `
+enum Column [class string unique, width int]:
+    ID = ("id", 8);
+`,
+		"unsigned value out of range": `
+enum Huge [uint64]:
+    MAX = 18446744073709551615;
+`,
+	}
+	for name, source := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, err := testutil.Compile(t, "java", source, nil)
+			if err == nil && len(ctx.Errors) == 0 && !ctx.Validations.HasErrors() {
+				t.Fatalf("expected the enum to be rejected")
+			}
+		})
+	}
+
+	for option, value := range map[string]string{"package": "com.example.class", "generate_jackson": "maybe"} {
+		ctx, err := testutil.Compile(t, "java", sampleSource, map[string]string{option: value})
+		if err == nil && len(ctx.Errors) == 0 {
+			t.Errorf("expected an invalid %s option to be rejected", option)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package java
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// enumTemplate renders a complete Java source file with a single enum, as
+// Java requires for public classes.
+const enumTemplate = "templates/enum.java.tmpl"
+
+var templateFuncs = template.FuncMap{
+	"javadoc": javadoc,
+	"join":    strings.Join,
+}
+
+// javadoc renders text as a Javadoc comment, followed by a @deprecated tag if
+// deprecated is set. Every line after the first is prefixed with indent so the
+// block lines up with the first line.
+func javadoc(indent, text, deprecated string) string {
+	var lines []string
+	if text != "" {
+		lines = strings.Split(text, "\n")
+	}
+	if deprecated != "" {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "@deprecated "+deprecated)
+	}
+	for i, line := range lines {
+		// Java translates \u escapes even in comments.
+		line = strings.ReplaceAll(line, `\u`, `&#92;u`)
+		lines[i] = strings.ReplaceAll(line, "*/", "*&#47;")
+	}
+
+	if len(lines) == 1 {
+		return "/** " + lines[0] + " */"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("/**\n")
+	for _, line := range lines {
+		sb.WriteString(indent + " *")
+		if line != "" {
+			sb.WriteString(" " + line)
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(indent + " */")
+	return sb.String()
+}
+
+func loadTemplate(name, path string, fs embed.FS) (*template.Template, error) {
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", path, err)
+	}
+
+	return tmpl, nil
+}
@@ -0,0 +1,113 @@
+package java
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+
+	"github.com/kkumar-gcc/enumgen/src/codegen/jvm"
+	"github.com/kkumar-gcc/enumgen/src/codegen/primitive"
+)
+
+// defaultValueTypes maps the primitives of the EDL type registry to Java.
+// Java has no unsigned integers, so unsigned types use the next wider
+// signed type.
+func defaultValueTypes() map[string]jvm.ValueType {
+	integer := func(edl, name, boxed string) jvm.ValueType {
+		return jvm.ValueType{Name: name, Boxed: boxed, Primitive: primitive.MustLookup(edl)}
+	}
+	return map[string]jvm.ValueType{
+		"char":    {Name: "char", Boxed: "Character", Primitive: primitive.MustLookup("char")},
+		"string":  {Name: "String", Boxed: "String", Primitive: primitive.MustLookup("string")},
+		"int":     integer("int", "long", "Long"),
+		"int8":    integer("int8", "byte", "Byte"),
+		"int32":   integer("int32", "int", "Integer"),
+		"int64":   integer("int64", "long", "Long"),
+		"uint":    integer("uint", "long", "Long"),
+		"uint8":   integer("uint8", "short", "Short"),
+		"uint32":  integer("uint32", "long", "Long"),
+		"uint64":  integer("uint64", "long", "Long"),
+		"float":   {Name: "float", Boxed: "Float", Primitive: primitive.MustLookup("float")},
+		"float32": {Name: "float", Boxed: "Float", Primitive: primitive.MustLookup("float32")},
+		"float64": {Name: "double", Boxed: "Double", Primitive: primitive.MustLookup("float64")},
+		"bool":    {Name: "boolean", Boxed: "Boolean", Primitive: primitive.MustLookup("bool")},
+	}
+}
+
+// literal renders a value decoded by the primitive type of t as a Java
+// expression of type t.
+func literal(t jvm.ValueType, v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return `"` + escape(v, '"') + `"`, nil
+	case rune:
+		if v > 0xFFFF {
+			return "", fmt.Errorf("character %q does not fit in a Java char", v)
+		}
+		return `'` + escape(string(v), '\'') + `'`, nil
+	case int64:
+		return intLiteral(t, strconv.FormatInt(v, 10)), nil
+	case uint64:
+		if v > math.MaxInt64 {
+			return "", fmt.Errorf("%d does not fit in a Java long", v)
+		}
+		return intLiteral(t, strconv.FormatUint(v, 10)), nil
+	case float64:
+		s := strconv.FormatFloat(v, 'g', -1, t.Primitive.BitSize)
+		if strings.ContainsAny(s, "IN") {
+			return "", fmt.Errorf("float %v cannot be written as a Java literal", v)
+		}
+		if !strings.ContainsAny(s, ".e") {
+			s += ".0"
+		}
+		if t.Name == "float" {
+			s += "f"
+		}
+		return s, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		return "", fmt.Errorf("internal error: unsupported value %#v", v)
+	}
+}
+
+// intLiteral adds the suffix or cast an integer literal needs to have type t.
+func intLiteral(t jvm.ValueType, s string) string {
+	switch t.Name {
+	case "long":
+		return s + "L"
+	case "byte", "short":
+		return "(" + t.Name + ") " + s
+	default:
+		return s
+	}
+}
+
+// escape escapes s for a Java string or character literal delimited by quote.
+func escape(s string, quote rune) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch {
+		case r == quote || r == '\\':
+			sb.WriteRune('\\')
+			sb.WriteRune(r)
+		case r == '\n':
+			sb.WriteString(`\n`)
+		case r == '\r':
+			sb.WriteString(`\r`)
+		case r == '\t':
+			sb.WriteString(`\t`)
+		case unicode.IsPrint(r):
+			sb.WriteRune(r)
+		case r > 0xFFFF:
+			r1, r2 := utf16.EncodeRune(r)
+			fmt.Fprintf(&sb, `\u%04x\u%04x`, r1, r2)
+		default:
+			fmt.Fprintf(&sb, `\u%04x`, r)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,5 @@
+package java
+
+const (
+	Version = "v0.0.1"
+)
@@ -0,0 +1,417 @@
+// Package jvm generates enums for the languages of the JVM. The languages
+// share the template data and how it is prepared, and differ in their
+// templates and in what a Language describes.
+package jvm
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/kkumar-gcc/enumgen/pkg/strcase"
+	"github.com/kkumar-gcc/enumgen/src/codegen/contracts"
+	"github.com/kkumar-gcc/enumgen/src/codegen/primitive"
+	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
+	"github.com/kkumar-gcc/enumgen/src/version"
+)
+
+var _ contracts.Generator = (*Generator)(nil)
+
+var identifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+type Generator struct {
+	lang           Language
+	enum           *template.Template
+	defaultOptions map[string]string
+	optionHelp     map[string]string
+}
+
+// NewGenerator returns a generator for lang that renders each enum with the
+// given template.
+func NewGenerator(lang Language, enum *template.Template) *Generator {
+	g := &Generator{
+		lang:           lang,
+		enum:           enum,
+		defaultOptions: make(map[string]string),
+		optionHelp:     make(map[string]string),
+	}
+	for _, opt := range options(lang) {
+		g.defaultOptions[opt.Key] = opt.DefaultValue
+		if opt.HelpText != "" {
+			g.optionHelp[opt.Key] = opt.HelpText
+		}
+	}
+	return g
+}
+
+func (g *Generator) Name() string {
+	return g.lang.Name
+}
+
+func (g *Generator) Language() string {
+	return g.lang.Code
+}
+
+func (g *Generator) DefaultOptions() map[string]string {
+	return g.defaultOptions
+}
+
+func (g *Generator) OptionHelp() string {
+	sb := strings.Builder{}
+	sb.WriteString("Available options for " + g.Name() + " code generation:\n")
+	for key, value := range g.DefaultOptions() {
+		help := g.optionHelp[key]
+		if help == "" {
+			sb.WriteString(fmt.Sprintf("  - %s (default: %s)\n", key, value))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("  - %s: %s (default: %s)\n", key, help, value))
+	}
+	return sb.String()
+}
+
+func (g *Generator) Generate(module compiler.IRModule, options map[string]string) ([]*compiler.OutputFile, error) {
+	opts := maps.Clone(g.DefaultOptions())
+	maps.Copy(opts, options)
+
+	pkg := opts[OptionPackage]
+	if err := g.validatePackage(pkg); err != nil {
+		return nil, err
+	}
+	outputPath := opts[OptionOutputPath]
+	if outputPath == "" {
+		outputPath = strings.ReplaceAll(pkg, ".", "/")
+	}
+	jackson, err := strconv.ParseBool(opts[OptionGenerateJackson])
+	if err != nil {
+		return nil, fmt.Errorf("invalid value '%s' for option '%s': %w", opts[OptionGenerateJackson], OptionGenerateJackson, err)
+	}
+
+	// Every enum gets a file of its own, named after it, as Java requires for
+	// public classes.
+	files := make([]*compiler.OutputFile, 0, len(module.Enums()))
+	for _, enum := range module.Enums() {
+		data, err := g.prepareTemplateData(enum)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate code for enum '%s': failed to prepare data: %w", enum.Name(), err)
+		}
+		data.Package = pkg
+		data.Jackson = jackson
+		data.Imports = g.lang.Imports(data)
+
+		var buf bytes.Buffer
+		if err := g.enum.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to generate code for enum '%s': failed to execute template: %w", enum.Name(), err)
+		}
+
+		files = append(files, &compiler.OutputFile{
+			Path: path.Join(outputPath, enum.Name()+g.lang.Extension),
+			Body: append(bytes.TrimRight(buf.Bytes(), "\n"), '\n'),
+		})
+	}
+
+	return files, nil
+}
+
+// validatePackage reports package names the compiler would not accept.
+func (g *Generator) validatePackage(pkg string) error {
+	for _, part := range strings.Split(pkg, ".") {
+		if !identifier.MatchString(part) || g.lang.Keywords[part] {
+			return fmt.Errorf("invalid %s package name '%s'", g.lang.Name, pkg)
+		}
+	}
+	return nil
+}
+
+func (g *Generator) prepareTemplateData(enum compiler.IREnumDefinition) (*TemplateData, error) {
+	if g.lang.Keywords[enum.Name()] {
+		return nil, fmt.Errorf("enum '%s' cannot be named after a %s keyword", enum.Name(), g.lang.Name)
+	}
+
+	fields, err := g.prepareFields(enum)
+	if err != nil {
+		return nil, err
+	}
+
+	valueTypeName := "string" // tuple members are keyed by their names
+	if fields == nil {
+		valueType := enum.ValueType()
+		if valueType == nil {
+			return nil, fmt.Errorf("enum '%s' has no value type defined", enum.Name())
+		}
+		valueTypeName = valueType.String()
+	}
+	valueType, ok := g.lang.Types[valueTypeName]
+	if !ok {
+		return nil, fmt.Errorf("type '%s' of enum '%s' has no %s equivalent", valueTypeName, enum.Name(), g.lang.Name)
+	}
+
+	keyType := valueType
+	if kt := enum.KeyType(); kt != nil {
+		if keyType, ok = g.lang.Types[kt.String()]; !ok {
+			return nil, fmt.Errorf("key type '%s' of enum '%s' has no %s equivalent", kt.String(), enum.Name(), g.lang.Name)
+		}
+	}
+
+	data := &TemplateData{
+		EDLVersion:     version.Version,
+		ToolVersion:    g.lang.Version,
+		EnumName:       enum.Name(),
+		EnumDoc:        enum.Doc(),
+		EnumDeprecated: deprecation(enum.Annotations(), enum.Name()),
+		ValueType:      valueType.Name,
+		ValueBoxed:     valueType.Boxed,
+		Fields:         fields,
+		KeyIsName:      fields != nil || enum.IsFlags(),
+		HasValues:      enum.KeyType() != nil || enum.IsFlags(),
+		IsFlags:        enum.IsFlags(),
+	}
+
+	switch {
+	case enum.IsFlags():
+		// Flags are keyed by their names and hold their bit as the value.
+		if valueType.Primitive.Kind != primitive.Int {
+			return nil, fmt.Errorf("flags enum '%s' requires an integer value type, got '%s'", enum.Name(), valueTypeName)
+		}
+		keyType = g.lang.Types["string"]
+		data.Params = []TemplateParam{{Type: valueType.Name, Var: "value"}}
+	case fields != nil:
+		for _, f := range fields {
+			data.Params = append(data.Params, TemplateParam{Type: f.Type, Var: f.Var})
+		}
+	case data.HasValues:
+		data.Params = []TemplateParam{{Type: keyType.Name, Var: "key"}, {Type: valueType.Name, Var: "value"}}
+	default:
+		data.Params = []TemplateParam{{Type: keyType.Name, Var: "key"}}
+	}
+	data.KeyType = keyType.Name
+	data.KeyBoxed = keyType.Boxed
+
+	// Members share their namespace with the fields of the enum class.
+	reserved := map[string]bool{g.lang.LookupMap("key"): true, g.lang.LookupMap("value"): true}
+	for name := range g.lang.ReservedMembers {
+		reserved[name] = true
+	}
+	for _, p := range data.Params {
+		reserved[p.Var] = true
+	}
+	for _, f := range fields {
+		reserved[f.Map] = true
+	}
+
+	keys := make([]string, 0, len(enum.Members()))
+	for i, member := range enum.Members() {
+		if err := g.checkName(member.Name(), reserved); err != nil {
+			return nil, fmt.Errorf("member '%s': %w", member.Name(), err)
+		}
+
+		var keyIR, valueIR compiler.IRValue
+		var args []string
+
+		switch v := member.Value().(type) {
+		case compiler.IRKeyValue:
+			keyIR = referencedValue(v.Key())
+			valueIR = referencedValue(v.Value())
+		case compiler.IRTuple:
+			if args, err = g.formatTuple(enum, v, member.Name()); err != nil {
+				return nil, err
+			}
+		default:
+			keyIR = referencedValue(member.Value())
+			valueIR = keyIR
+		}
+
+		var key any = member.Name()
+		if !data.KeyIsName {
+			key, err = keyType.Primitive.Decode(keyIR, member.Name(), i)
+			if err != nil {
+				return nil, fmt.Errorf("error formatting key for member '%s': %w", member.Name(), err)
+			}
+			keyLiteral, err := g.lang.Literal(keyType, key)
+			if err != nil {
+				return nil, fmt.Errorf("invalid key for member '%s': %w", member.Name(), err)
+			}
+			args = append(args, keyLiteral)
+		}
+		if data.HasValues {
+			value, err := valueType.Primitive.Decode(valueIR, member.Name(), i)
+			if err != nil {
+				return nil, fmt.Errorf("error formatting value for member '%s': %w", member.Name(), err)
+			}
+			valueLiteral, err := g.lang.Literal(valueType, value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for member '%s': %w", member.Name(), err)
+			}
+			args = append(args, valueLiteral)
+		}
+
+		aliases := member.Aliases()
+		if len(aliases) > 0 && keyType.Name != "String" {
+			return nil, fmt.Errorf("member '%s' declares aliases, but the key type is '%s'", member.Name(), keyType.Name)
+		}
+		var lookups []string
+		for _, alias := range aliases {
+			lit, _ := g.lang.Literal(keyType, alias)
+			lookups = append(lookups, lit)
+		}
+		data.HasAliases = data.HasAliases || len(aliases) > 0
+
+		data.Members = append(data.Members, TemplateMember{
+			Name:       member.Name(),
+			Doc:        member.Doc(),
+			Deprecated: deprecation(member.Annotations(), member.Name()),
+			Args:       strings.Join(args, ", "),
+			Aliases:    lookups,
+		})
+		keys = append(keys, keyString(key))
+	}
+	data.ValidKeys = g.lang.Escape("one of: "+strings.Join(keys, ", "), '"')
+
+	for _, member := range enum.MemberAliases() {
+		ref, ok := member.Value().(compiler.IRReference)
+		if !ok {
+			return nil, fmt.Errorf("member '%s' is not assigned another member", member.Name())
+		}
+		if err := g.checkName(member.Name(), reserved); err != nil {
+			return nil, fmt.Errorf("member '%s': %w", member.Name(), err)
+		}
+		data.MemberAliases = append(data.MemberAliases, TemplateMemberAlias{
+			Name:       member.Name(),
+			Target:     ref.Member(),
+			Doc:        member.Doc(),
+			Deprecated: deprecation(member.Annotations(), member.Name()),
+		})
+	}
+
+	return data, nil
+}
+
+// checkName reports member names the language cannot use for enum constants.
+func (g *Generator) checkName(name string, reserved map[string]bool) error {
+	switch {
+	case g.lang.Keywords[name]:
+		return fmt.Errorf("'%s' is a %s keyword", name, g.lang.Name)
+	case reserved[name]:
+		return fmt.Errorf("'%s' would clash with a member of the enum class", name)
+	}
+	return nil
+}
+
+// prepareFields returns the fields of a tuple enum, or nil for other enums.
+func (g *Generator) prepareFields(enum compiler.IREnumDefinition) ([]TemplateField, error) {
+	if len(enum.Fields()) == 0 {
+		return nil, nil
+	}
+
+	fields := make([]TemplateField, 0, len(enum.Fields()))
+	owners := make(map[string]string)
+	for _, field := range enum.Fields() {
+		if field.Type() == nil {
+			return nil, fmt.Errorf("field '%s' of enum '%s' has no type", field.Name(), enum.Name())
+		}
+
+		typeName, boxed := field.Type().Name(), field.Type().Name()
+		if field.Type().Kind() != compiler.TypeEnum {
+			t, ok := g.lang.Types[field.Type().String()]
+			if !ok {
+				return nil, fmt.Errorf("type '%s' of field '%s' in enum '%s' has no %s equivalent", field.Type().String(), field.Name(), enum.Name(), g.lang.Name)
+			}
+			typeName, boxed = t.Name, t.Boxed
+		}
+
+		name := strcase.ToPascal(field.Name())
+		f := TemplateField{
+			Name:   field.Name(),
+			Var:    strcase.ToCamel(field.Name()),
+			Type:   typeName,
+			Boxed:  boxed,
+			Unique: field.IsUnique(),
+		}
+		f.Accessor = g.lang.Accessor(name, f.Var)
+		if f.Unique {
+			f.Lookup = "from" + name
+			f.Map = g.lang.LookupMap(field.Name())
+		}
+		if g.lang.Keywords[f.Var] {
+			return nil, fmt.Errorf("field '%s' of enum '%s' cannot be named after a %s keyword", field.Name(), enum.Name(), g.lang.Name)
+		}
+		if g.lang.ReservedAccessors[f.Accessor] || g.lang.ReservedLookups[f.Lookup] {
+			return nil, fmt.Errorf("field '%s' of enum '%s' would replace a member of the enum class", field.Name(), enum.Name())
+		}
+		if prev, ok := owners[f.Var]; ok {
+			return nil, fmt.Errorf("fields '%s' and '%s' of enum '%s' both map to '%s'", prev, field.Name(), enum.Name(), f.Var)
+		}
+		owners[f.Var] = field.Name()
+
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// formatTuple renders the values of a tuple member in field order. Fields of
+// an enum type hold references to its members.
+func (g *Generator) formatTuple(enum compiler.IREnumDefinition, tuple compiler.IRTuple, memberName string) ([]string, error) {
+	fields := enum.Fields()
+	if len(tuple.Elements()) != len(fields) {
+		return nil, fmt.Errorf("member '%s' has %d values, but enum '%s' declares %d fields", memberName, len(tuple.Elements()), enum.Name(), len(fields))
+	}
+
+	values := make([]string, len(fields))
+	for i, elt := range tuple.Elements() {
+		fieldType := fields[i].Type()
+		if fieldType.Kind() == compiler.TypeEnum {
+			ref, ok := elt.(compiler.IRReference)
+			if !ok || ref.Enum() != fieldType.Name() {
+				return nil, fmt.Errorf("field '%s' of member '%s' must reference a member of '%s', got %v", fields[i].Name(), memberName, fieldType.Name(), elt)
+			}
+			values[i] = ref.Enum() + "." + ref.Member()
+			continue
+		}
+
+		t := g.lang.Types[fieldType.String()]
+		value, err := t.Primitive.Decode(referencedValue(elt), memberName, i)
+		if err == nil {
+			values[i], err = g.lang.Literal(t, value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error formatting field '%s' of member '%s': %w", fields[i].Name(), memberName, err)
+		}
+	}
+	return values, nil
+}
+
+// referencedValue returns the value a member reference stands for, or v
+// itself if it is not a reference.
+func referencedValue(v compiler.IRValue) compiler.IRValue {
+	if ref, ok := v.(compiler.IRReference); ok {
+		return ref.Target()
+	}
+	return v
+}
+
+// deprecation returns the deprecation notice of a @deprecated declaration named name, or "".
+func deprecation(annotations []compiler.IRAnnotation, name string) string {
+	for _, a := range annotations {
+		if a.Name() != "deprecated" {
+			continue
+		}
+		if len(a.Args()) > 0 && a.Args()[0] != "" {
+			return a.Args()[0]
+		}
+		return name + " should no longer be used."
+	}
+	return ""
+}
+
+// keyString renders a key for error messages.
+func keyString(key any) string {
+	if r, ok := key.(rune); ok {
+		return string(r)
+	}
+	return fmt.Sprint(key)
+}
@@ -0,0 +1,54 @@
+package jvm
+
+import "github.com/kkumar-gcc/enumgen/src/codegen/primitive"
+
+// Language describes what sets a JVM language apart when generating enums:
+// its names, types and literals. The template data is the same for all of
+// them, so each language only brings its template and a Language.
+type Language struct {
+	Name      string // e.g. Java, used in option help and error messages
+	Code      string // language code the generator is registered under, e.g. java
+	Version   string // version of the generator
+	Extension string // source file extension, e.g. .java
+
+	// Keywords cannot name packages, enums, members or fields.
+	Keywords map[string]bool
+
+	// ReservedMembers are names of the enum class, besides its lookup maps
+	// and constructor parameters, that members cannot take.
+	ReservedMembers map[string]bool
+
+	// ReservedAccessors and ReservedLookups are members of the enum class
+	// that the accessor and the lookup of a field must not replace.
+	ReservedAccessors map[string]bool
+	ReservedLookups   map[string]bool
+
+	// Types maps the primitives of the EDL type registry to the language.
+	Types map[string]ValueType
+
+	// Accessor returns the member a field is read through, given the field
+	// name in PascalCase and camelCase.
+	Accessor func(pascal, camel string) string
+
+	// LookupMap returns the name of the map looking members up by the field
+	// with the given EDL name, or by "key" or "value".
+	LookupMap func(field string) string
+
+	// Literal renders a value decoded by the primitive type of t as an
+	// expression of type t.
+	Literal func(t ValueType, v any) (string, error)
+
+	// Escape escapes s for a string or character literal delimited by quote.
+	Escape func(s string, quote rune) string
+
+	// Imports returns the classes the enum described by data refers to.
+	Imports func(data *TemplateData) []string
+}
+
+// ValueType is the type an EDL primitive has in a JVM language and the
+// primitive type that decodes its literals.
+type ValueType struct {
+	Name      string // e.g. int
+	Boxed     string // class used as a type argument, e.g. Integer, if the language tells them apart
+	Primitive primitive.Type
+}
@@ -0,0 +1,34 @@
+package jvm
+
+const (
+	OptionPackage         = "package"
+	OptionOutputPath      = "output_path"
+	OptionGenerateJackson = "generate_jackson"
+)
+
+type OptionDef struct {
+	Key          string
+	DefaultValue string
+	HelpText     string
+}
+
+// options returns the options of the generator for lang.
+func options(lang Language) []OptionDef {
+	return []OptionDef{
+		{
+			Key:          OptionPackage,
+			DefaultValue: "enums",
+			HelpText:     "The " + lang.Name + " package of the generated enums.",
+		},
+		{
+			Key:          OptionOutputPath,
+			DefaultValue: "",
+			HelpText:     "The directory the enums are written to, relative to the output directory (default: the directory of the package, e.g. 'com/example/enums').",
+		},
+		{
+			Key:          OptionGenerateJackson,
+			DefaultValue: "false",
+			HelpText:     "If true, annotates the key with @JsonValue and fromKey with @JsonCreator, so Jackson encodes members by their key.",
+		},
+	}
+}
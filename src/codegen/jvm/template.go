@@ -0,0 +1,61 @@
+package jvm
+
+type TemplateMember struct {
+	Name       string
+	Doc        string
+	Deprecated string   // deprecation notice from @deprecated, if any
+	Args       string   // arguments passed to the constructor
+	Aliases    []string // literals of further keys fromKey accepts for the member
+}
+
+// TemplateMemberAlias is a member assigned another member of its enum, which
+// becomes a constant holding that member.
+type TemplateMemberAlias struct {
+	Name       string
+	Target     string
+	Doc        string
+	Deprecated string
+}
+
+// TemplateField is a named field of a tuple enum.
+type TemplateField struct {
+	Name     string // field name in EDL
+	Var      string // field or property and parameter name, e.g. statusCode
+	Accessor string // member the field is read through, e.g. getStatusCode in Java
+	Type     string // type of the field
+	Boxed    string // type of the field as a type argument
+	Lookup   string // name of the lookup method of a unique field, e.g. fromStatusCode
+	Map      string // name of the lookup map of a unique field, e.g. BY_STATUS_CODE in Java
+	Unique   bool   // whether members can be looked up by the field's value
+}
+
+// TemplateParam is a parameter of the enum constructor, which is stored in a
+// field of the same name.
+type TemplateParam struct {
+	Type string
+	Var  string
+}
+
+type TemplateData struct {
+	EDLVersion     string
+	ToolVersion    string
+	Package        string
+	Imports        []string
+	EnumName       string
+	EnumDoc        string
+	EnumDeprecated string
+	KeyType        string
+	KeyBoxed       string
+	ValueType      string
+	ValueBoxed     string
+	Members        []TemplateMember
+	MemberAliases  []TemplateMemberAlias
+	Fields         []TemplateField
+	Params         []TemplateParam
+	KeyIsName      bool // whether members are keyed by their names, as in tuple and flag enums
+	HasValues      bool // whether members pair a key with a value
+	HasAliases     bool
+	IsFlags        bool
+	Jackson        bool
+	ValidKeys      string // escaped for use in a string literal
+}
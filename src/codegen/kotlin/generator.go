@@ -0,0 +1,73 @@
+package kotlin
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/kkumar-gcc/enumgen/pkg/strcase"
+	"github.com/kkumar-gcc/enumgen/src/codegen/jvm"
+)
+
+// keywords are the hard keywords of Kotlin, which cannot name packages,
+// enums, entries or properties without backticks.
+var keywords = map[string]bool{
+	"as": true, "break": true, "class": true, "continue": true, "do": true,
+	"else": true, "false": true, "for": true, "fun": true, "if": true,
+	"in": true, "interface": true, "is": true, "null": true, "object": true,
+	"package": true, "return": true, "super": true, "this": true, "throw": true,
+	"true": true, "try": true, "typealias": true, "typeof": true, "val": true,
+	"var": true, "when": true, "while": true,
+}
+
+// reservedProperties are properties of generated enums, or inherited from
+// kotlin.Enum, that fields must not replace.
+var reservedProperties = map[string]bool{
+	"key": true, "name": true, "ordinal": true, "entries": true,
+}
+
+// reservedFunctions are functions of the companion object of generated
+// enums, which lookups by field must not replace.
+var reservedFunctions = map[string]bool{
+	"fromKey": true, "fromValue": true, "fromBits": true, "toBits": true,
+	"values": true, "valueOf": true,
+}
+
+func New() (*jvm.Generator, error) {
+	enum, err := loadTemplate("enum", enumTemplate, templatesFS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	return jvm.NewGenerator(jvm.Language{
+		Name:      "Kotlin",
+		Code:      "kotlin",
+		Version:   Version,
+		Extension: ".kt",
+		Keywords:  keywords,
+		// Entries share their namespace with the properties of the enum and
+		// its companion object.
+		ReservedMembers:   reservedProperties,
+		ReservedAccessors: reservedProperties,
+		ReservedLookups:   reservedFunctions,
+		Types:             defaultValueTypes(),
+		Accessor:          func(_, camel string) string { return camel },
+		LookupMap:         func(field string) string { return "by" + strcase.ToPascal(field) },
+		Literal:           literal,
+		Escape:            escape,
+		Imports:           imports,
+	}, enum), nil
+}
+
+// imports returns the classes the enum described by data refers to beyond
+// the Kotlin standard library, sorted.
+func imports(data *jvm.TemplateData) []string {
+	var imports []string
+	if data.IsFlags {
+		imports = append(imports, "java.util.EnumSet")
+	}
+	if data.Jackson {
+		imports = append(imports, "com.fasterxml.jackson.annotation.JsonCreator", "com.fasterxml.jackson.annotation.JsonValue")
+	}
+	slices.Sort(imports)
+	return imports
+}
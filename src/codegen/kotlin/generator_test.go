@@ -0,0 +1,162 @@
+package kotlin_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kkumar-gcc/enumgen/src/codegen/internal/testutil"
+)
+
+const sampleSource = testutil.SampleSource + `
+enum HTTPStatus [code int32 unique, text string, status Status, status_type string]:
+    OK = (200, "OK", Status.SUCCESS, "success"),
+    UNAVAILABLE = (503, "Service $Unavailable", Status.FAILURE, "error");
+
+enum Ratio [float32]:
+    ONE = 1,
+    HALF = 0.5;
+`
+
+func TestGenerate(t *testing.T) {
+	files := testutil.Generate(t, "kotlin", sampleSource, nil)
+	if len(files) != 6 {
+		t.Fatalf("expected a file per enum, got %v", files)
+	}
+
+	testutil.AssertContains(t, files["enums/Status.kt"],
+		"package enums\n\n/** Status of an operation. */\nenum class Status(val key: Long) {\n    SUCCESS(1),\n",
+		"        /** FAILURE is an alias of [ERROR]. */\n        @JvmField\n        val FAILURE: Status = ERROR\n",
+		"    override fun toString(): String = key.toString()",
+		"        fun fromKey(key: Long): Status =",
+	)
+	testutil.AssertContains(t, files["enums/Color.kt"],
+		"    @Deprecated(\"use RED\")\n    GREEN(\"green\"),",
+		"            put(\"crimson\", RED)",
+		"    override fun toString(): String = key\n",
+		"\"invalid Color key $key, expected one of: red, green, blue\"",
+	)
+	testutil.AssertContains(t, files["enums/Day.kt"],
+		"enum class Day(val key: String, val value: UByte) {\n    MONDAY(\"Monday\", 1u),",
+		"        fun fromValue(value: UByte): Day =",
+	)
+	testutil.AssertContains(t, files["enums/HTTPStatus.kt"],
+		"enum class HTTPStatus(val code: Int, val text: String, val status: Status, val statusType: String) {",
+		"    UNAVAILABLE(503, \"Service \\$Unavailable\", Status.FAILURE, \"error\"),",
+		"    val key: String\n        get() = name",
+		"        private val byCode: Map<Int, HTTPStatus> = values().associateBy { it.code }",
+		"        fun fromCode(code: Int): HTTPStatus =",
+	)
+	testutil.AssertContains(t, files["enums/Perm.kt"],
+		"import java.util.EnumSet\n",
+		"enum class Perm(val value: UByte) {",
+		"    EXEC(4u),",
+		"            put(\"W\", WRITE)",
+		"        fun fromBits(bits: Long): Set<Perm> {",
+		"        fun toBits(flags: Set<Perm>): Long =",
+	)
+	testutil.AssertContains(t, files["enums/Ratio.kt"], "    HALF(0.5f),")
+
+	if strings.Contains(files["enums/HTTPStatus.kt"], "fromText") {
+		t.Errorf("fields not marked unique should not have a lookup")
+	}
+	if strings.Contains(files["enums/Color.kt"], "jackson") {
+		t.Errorf("Jackson annotations should only be generated on request")
+	}
+}
+
+func TestGenerateAliases(t *testing.T) {
+	files := testutil.Generate(t, "kotlin", testutil.AliasSource, nil)
+
+	testutil.AssertContains(t, files["enums/Mode.kt"],
+		"enum class Mode(val value: UInt) {\n    READ(1u),\n    WRITE(2u),\n    EXEC(4u),\n    ;\n",
+		"        /** RUN is an alias of [EXEC]. */\n        @JvmField\n        val RUN: Mode = EXEC\n",
+		"            put(\"r\", READ)\n            put(\"w\", WRITE)\n",
+		"            for (member in values()) putIfAbsent(member.value, member)",
+		"\"invalid Mode key $key, expected one of: READ, WRITE, EXEC\"",
+	)
+	testutil.AssertContains(t, files["enums/Point.kt"],
+		"    ORIGIN(0, 0, \"origin\"),\n    UNIT(1, 1, \"unit\"),\n    ;\n",
+		"        /** ZERO is an alias of [ORIGIN]. */\n        @JvmField\n        val ZERO: Point = ORIGIN\n",
+		"        private val byX: Map<Long, Point> = values().associateBy { it.x }",
+		"        private val byLabel: Map<String, Point> = values().associateBy { it.label }",
+		"        fun fromX(x: Long): Point =",
+		"        fun fromLabel(label: String): Point =",
+	)
+	if strings.Contains(files["enums/Point.kt"], "fromY") {
+		t.Errorf("fields not marked unique should not have a lookup")
+	}
+}
+
+func TestGenerateCompiles(t *testing.T) {
+	kotlinc := testutil.LookPath(t, "kotlinc")
+	classpath := testutil.JacksonClasspath(t)
+
+	dir := t.TempDir()
+	files := testutil.Generate(t, "kotlin", sampleSource+testutil.AliasSource, map[string]string{
+		"package":          "com.example.enums",
+		"generate_jackson": "true",
+	})
+	paths := testutil.WriteFiles(t, filepath.Join(dir, "src"), files)
+	args := append([]string{"-cp", classpath, "-d", filepath.Join(dir, "classes")}, paths...)
+	testutil.Run(t, dir, kotlinc, args...)
+}
+
+func TestGenerateOptions(t *testing.T) {
+	files := testutil.Generate(t, "kotlin", sampleSource, map[string]string{
+		"package":          "com.example.enums",
+		"generate_jackson": "true",
+	})
+
+	code, ok := files["com/example/enums/Color.kt"]
+	if !ok {
+		t.Fatalf("expected files in the directory of the package, got %v", files)
+	}
+	testutil.AssertContains(t, code,
+		"package com.example.enums\n\nimport com.fasterxml.jackson.annotation.JsonCreator\nimport com.fasterxml.jackson.annotation.JsonValue\n",
+		"enum class Color(@get:JsonValue val key: String) {",
+		"        @JvmStatic\n        @JsonCreator\n        fun fromKey(key: String): Color =",
+	)
+	testutil.AssertContains(t, files["com/example/enums/Perm.kt"], "    @get:JsonValue\n    val key: String\n")
+
+	files = testutil.Generate(t, "kotlin", sampleSource, map[string]string{"output_path": "."})
+	if _, ok := files["Color.kt"]; !ok {
+		t.Errorf("expected output_path to replace the directory of the package, got %v", files)
+	}
+}
+
+func TestGenerateInvalid(t *testing.T) {
+	tests := map[string]string{
+		"keyword member": `
+enum Keyword [string]:
+    when = "when";
+`,
+		"reserved member": `
+enum Lookup [string]:
+    byKey = "key";
+`,
+		"reserved field": `
+enum Column [name string unique, width int]:
+    ID = ("id", 8);
+`,
+		"keyword field": `
+enum Column [val string unique, width int]:
+    ID = ("id", 8);
+`,
+	}
+	for name, source := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, err := testutil.Compile(t, "kotlin", source, nil)
+			if err == nil && len(ctx.Errors) == 0 && !ctx.Validations.HasErrors() {
+				t.Fatalf("expected the enum to be rejected")
+			}
+		})
+	}
+
+	for option, value := range map[string]string{"package": "com.example.object", "generate_jackson": "maybe"} {
+		ctx, err := testutil.Compile(t, "kotlin", sampleSource, map[string]string{option: value})
+		if err == nil && len(ctx.Errors) == 0 {
+			t.Errorf("expected an invalid %s option to be rejected", option)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package kotlin
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// enumTemplate renders a complete Kotlin source file with a single enum, named
+// after the enum as the Kotlin conventions suggest.
+const enumTemplate = "templates/enum.kt.tmpl"
+
+var templateFuncs = template.FuncMap{
+	"kdoc":  kdoc,
+	"join":  strings.Join,
+	"quote": quote,
+}
+
+// kdoc renders text as a KDoc comment. Every line after the first is
+// prefixed with indent so the block lines up with the first line. Deprecation
+// is declared with the @Deprecated annotation instead of a tag.
+func kdoc(indent, text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		// Block comments nest in Kotlin, so openers need escaping as well.
+		line = strings.ReplaceAll(line, "/*", "/&#42;")
+		lines[i] = strings.ReplaceAll(line, "*/", "&#42;/")
+	}
+
+	if len(lines) == 1 {
+		return "/** " + lines[0] + " */"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("/**\n")
+	for _, line := range lines {
+		sb.WriteString(indent + " *")
+		if line != "" {
+			sb.WriteString(" " + line)
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(indent + " */")
+	return sb.String()
+}
+
+// quote renders s as a Kotlin string literal.
+func quote(s string) string {
+	return `"` + escape(s, '"') + `"`
+}
+
+func loadTemplate(name, path string, fs embed.FS) (*template.Template, error) {
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", path, err)
+	}
+
+	return tmpl, nil
+}
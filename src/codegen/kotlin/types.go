@@ -0,0 +1,102 @@
+package kotlin
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+
+	"github.com/kkumar-gcc/enumgen/src/codegen/jvm"
+	"github.com/kkumar-gcc/enumgen/src/codegen/primitive"
+)
+
+// defaultValueTypes maps the primitives of the EDL type registry to Kotlin.
+func defaultValueTypes() map[string]jvm.ValueType {
+	integer := func(edl, name string) jvm.ValueType {
+		return jvm.ValueType{Name: name, Primitive: primitive.MustLookup(edl)}
+	}
+	return map[string]jvm.ValueType{
+		"char":    {Name: "Char", Primitive: primitive.MustLookup("char")},
+		"string":  {Name: "String", Primitive: primitive.MustLookup("string")},
+		"int":     integer("int", "Long"),
+		"int8":    integer("int8", "Byte"),
+		"int32":   integer("int32", "Int"),
+		"int64":   integer("int64", "Long"),
+		"uint":    integer("uint", "ULong"),
+		"uint8":   integer("uint8", "UByte"),
+		"uint32":  integer("uint32", "UInt"),
+		"uint64":  integer("uint64", "ULong"),
+		"float":   {Name: "Float", Primitive: primitive.MustLookup("float")},
+		"float32": {Name: "Float", Primitive: primitive.MustLookup("float32")},
+		"float64": {Name: "Double", Primitive: primitive.MustLookup("float64")},
+		"bool":    {Name: "Boolean", Primitive: primitive.MustLookup("bool")},
+	}
+}
+
+// literal renders a value decoded by the primitive type of t as a Kotlin
+// expression of type t. Integer literals take their type from the parameter
+// they are passed to.
+func literal(t jvm.ValueType, v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return `"` + escape(v, '"') + `"`, nil
+	case rune:
+		if v > 0xFFFF {
+			return "", fmt.Errorf("character %q does not fit in a Kotlin Char", v)
+		}
+		return `'` + escape(string(v), '\'') + `'`, nil
+	case int64:
+		if v == math.MinInt64 {
+			// The literal would overflow before being negated.
+			return "Long.MIN_VALUE", nil
+		}
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10) + "u", nil
+	case float64:
+		s := strconv.FormatFloat(v, 'g', -1, t.Primitive.BitSize)
+		if strings.ContainsAny(s, "IN") {
+			return "", fmt.Errorf("float %v cannot be written as a Kotlin literal", v)
+		}
+		if !strings.ContainsAny(s, ".e") {
+			s += ".0"
+		}
+		if t.Name == "Float" {
+			s += "f"
+		}
+		return s, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		return "", fmt.Errorf("internal error: unsupported value %#v", v)
+	}
+}
+
+// escape escapes s for a Kotlin string or character literal delimited by
+// quote. Dollar signs are escaped so they do not start string templates.
+func escape(s string, quote rune) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch {
+		case r == quote || r == '\\' || r == '$':
+			sb.WriteRune('\\')
+			sb.WriteRune(r)
+		case r == '\n':
+			sb.WriteString(`\n`)
+		case r == '\r':
+			sb.WriteString(`\r`)
+		case r == '\t':
+			sb.WriteString(`\t`)
+		case unicode.IsPrint(r):
+			sb.WriteRune(r)
+		case r > 0xFFFF:
+			r1, r2 := utf16.EncodeRune(r)
+			fmt.Fprintf(&sb, `\u%04x\u%04x`, r1, r2)
+		default:
+			fmt.Fprintf(&sb, `\u%04x`, r)
+		}
+	}
+	return sb.String()
+}
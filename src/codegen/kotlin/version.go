@@ -0,0 +1,5 @@
+package kotlin
+
+const (
+	Version = "v0.0.1"
+)
@@ -0,0 +1,110 @@
+// Package output decides where generators write their files. It implements the
+// output_mode and file_name options shared by the generators that can write
+// either one file per enum or one file per EDL source.
+package output
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
+)
+
+type Mode string
+
+const (
+	// PerEnum writes each enum to its own file
+	PerEnum Mode = "per_enum"
+
+	// Single writes all enums of a source file to one combined file
+	Single Mode = "single"
+
+	// Unknown is used for unrecognized output modes
+	Unknown Mode = "unknown"
+)
+
+const (
+	// fileNameEnum is replaced by the lower-cased enum name in file name patterns
+	fileNameEnum = "{enum}"
+
+	// fileNameSource is replaced by the EDL file name without its extension in file name patterns
+	fileNameSource = "{file}"
+)
+
+func (m Mode) String() string {
+	return string(m)
+}
+
+func ParseMode(mode string) Mode {
+	switch mode {
+	case string(PerEnum):
+		return PerEnum
+	case string(Single):
+		return Single
+	default:
+		return Unknown
+	}
+}
+
+// Resolve parses the output mode and file name pattern options. An empty
+// pattern falls back to the default one, which ends in suffix, such as
+// "_gen.go" or ".py".
+func Resolve(mode string, pattern string, suffix string) (Mode, string, error) {
+	outputMode := ParseMode(mode)
+	if outputMode == Unknown {
+		return Unknown, "", fmt.Errorf("unknown output mode '%s'", mode)
+	}
+	if pattern == "" {
+		pattern = DefaultPattern(outputMode, suffix)
+	}
+	if err := ValidatePattern(pattern, outputMode); err != nil {
+		return Unknown, "", err
+	}
+	return outputMode, pattern, nil
+}
+
+// DefaultPattern returns the file name pattern used when none is configured.
+func DefaultPattern(mode Mode, suffix string) string {
+	if mode == Single {
+		return fileNameSource + suffix
+	}
+	return fileNameEnum + suffix
+}
+
+// FileName expands a file name pattern for the given source file and enum.
+func FileName(pattern string, sourcePath string, enumName string) string {
+	source := filepath.Base(sourcePath)
+	source = strings.TrimSuffix(source, filepath.Ext(source))
+
+	name := strings.ReplaceAll(pattern, fileNameEnum, strings.ToLower(enumName))
+	return strings.ReplaceAll(name, fileNameSource, strings.ToLower(source))
+}
+
+// FileNames expands a per-enum file name pattern for every enum of module,
+// keyed by enum name. Since enum names are lower-cased, enums such as Color
+// and COLOR would overwrite each other's file and are reported instead.
+func FileNames(pattern string, module compiler.IRModule) (map[string]string, error) {
+	names := make(map[string]string, len(module.Enums()))
+	owners := make(map[string]string, len(module.Enums()))
+	for _, enum := range module.Enums() {
+		name := FileName(pattern, module.Name(), enum.Name())
+		if prev, ok := owners[name]; ok {
+			return nil, fmt.Errorf("enums '%s' and '%s' both map to the file '%s'", prev, enum.Name(), name)
+		}
+		owners[name] = enum.Name()
+		names[enum.Name()] = name
+	}
+	return names, nil
+}
+
+// ValidatePattern makes sure a pattern yields distinct names in the given mode.
+func ValidatePattern(pattern string, mode Mode) error {
+	if mode == PerEnum && !strings.Contains(pattern, fileNameEnum) {
+		return fmt.Errorf("file name pattern '%s' must contain %s when output mode is '%s'", pattern, fileNameEnum, mode)
+	}
+	if mode == Single && strings.Contains(pattern, fileNameEnum) {
+		return fmt.Errorf("file name pattern '%s' cannot contain %s when output mode is '%s'", pattern, fileNameEnum, mode)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package output_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kkumar-gcc/enumgen/src/codegen/output"
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		mode    string
+		pattern string
+		want    string
+		err     string
+	}{
+		{mode: "per_enum", want: "{enum}.rs"},
+		{mode: "single", want: "{file}.rs"},
+		{mode: "single", pattern: "lib/{file}_enums.rs", want: "lib/{file}_enums.rs"},
+		{mode: "bogus", err: "unknown output mode 'bogus'"},
+		{mode: "per_enum", pattern: "enums.rs", err: "must contain {enum}"},
+		{mode: "single", pattern: "{enum}.rs", err: "cannot contain {enum}"},
+	}
+	for _, tt := range tests {
+		_, pattern, err := output.Resolve(tt.mode, tt.pattern, ".rs")
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Resolve(%q, %q): expected error %q, got %v", tt.mode, tt.pattern, tt.err, err)
+			}
+			continue
+		}
+		if err != nil || pattern != tt.want {
+			t.Errorf("Resolve(%q, %q) = %q, %v; want %q", tt.mode, tt.pattern, pattern, err, tt.want)
+		}
+	}
+}
+
+func TestFileName(t *testing.T) {
+	if got := output.FileName("gen/{file}_{enum}.ts", "defs/Shared.edl", "HTTPStatus"); got != "gen/shared_httpstatus.ts" {
+		t.Errorf("unexpected file name: %s", got)
+	}
+}
@@ -0,0 +1,175 @@
+package primitive
+
+import (
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
+	"github.com/kkumar-gcc/enumgen/src/token"
+)
+
+// Decode decodes the value of the member memberName, at position index of
+// its enum, into a string, rune, int64, uint64, float64 or bool according to
+// the kind of t. A nil value yields the default of the kind: the member name
+// for strings, and the index for characters and integers.
+func (t Type) Decode(irValue compiler.IRValue, memberName string, index int) (any, error) {
+	switch t.Kind {
+	case String:
+		return decodeString(irValue, memberName)
+	case Char:
+		return decodeChar(irValue, memberName, index)
+	case Int:
+		return t.decodeInt(irValue, memberName, index)
+	case Float:
+		return t.decodeFloat(irValue, memberName)
+	case Bool:
+		return decodeBool(irValue, memberName)
+	default:
+		return nil, fmt.Errorf("internal error: unrecognized primitive type '%s'", t.Name)
+	}
+}
+
+func decodeString(irValue compiler.IRValue, memberName string) (any, error) {
+	if irValue == nil {
+		return memberName, nil
+	}
+
+	literal, ok := irValue.(compiler.IRLiteral)
+	if !ok {
+		return nil, fmt.Errorf("internal error: expected IRLiteral for string handler, got %T", irValue)
+	}
+
+	if literal.Kind() != token.STRING {
+		return nil, fmt.Errorf("type error for member '%s': string enum expects a STRING literal, got %v", memberName, literal.Kind())
+	}
+
+	unquoted, err := strconv.Unquote(literal.Value())
+	if err != nil {
+		return nil, fmt.Errorf("syntax error for member '%s': invalid string literal %s", memberName, literal.Value())
+	}
+
+	return unquoted, nil
+}
+
+func decodeChar(irValue compiler.IRValue, memberName string, index int) (any, error) {
+	if irValue == nil {
+		return rune(index), nil
+	}
+
+	literal, ok := irValue.(compiler.IRLiteral)
+	if !ok {
+		return nil, fmt.Errorf("internal error: expected IRLiteral for char handler, got %T", irValue)
+	}
+
+	if literal.Kind() != token.CHAR {
+		return nil, fmt.Errorf("type error for member '%s': char enum expects a CHAR literal, got %v", memberName, literal.Kind())
+	}
+
+	unquoted, err := strconv.Unquote(literal.Value())
+	if err != nil {
+		return nil, fmt.Errorf("syntax error for member '%s': invalid character literal %s", memberName, literal.Value())
+	}
+
+	if utf8.RuneCountInString(unquoted) != 1 {
+		return nil, fmt.Errorf("type error for member '%s': character literal %s must contain exactly one character", memberName, literal.Value())
+	}
+
+	charRune, _ := utf8.DecodeRuneInString(unquoted)
+
+	return charRune, nil
+}
+
+func (t Type) decodeInt(irValue compiler.IRValue, memberName string, index int) (any, error) {
+	numericStr := strconv.Itoa(index)
+	if irValue != nil {
+		s, kind, err := numericString(irValue)
+		if err != nil {
+			return nil, fmt.Errorf("for member '%s': %w", memberName, err)
+		}
+		if kind != token.INT {
+			return nil, fmt.Errorf("for member '%s': type error: expected an INT literal, got %v", memberName, kind)
+		}
+		numericStr = s
+	}
+
+	if t.Unsigned {
+		val, err := strconv.ParseUint(numericStr, 0, t.BitSize)
+		if err != nil {
+			return nil, fmt.Errorf("invalid literal for member '%s': cannot parse '%s' as %s", memberName, numericStr, t.Name)
+		}
+
+		return val, nil
+	}
+
+	val, err := strconv.ParseInt(numericStr, 0, t.BitSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid literal for member '%s': cannot parse '%s' as %s", memberName, numericStr, t.Name)
+	}
+
+	return val, nil
+}
+
+func (t Type) decodeFloat(irValue compiler.IRValue, memberName string) (any, error) {
+	numericStr, kind, err := numericString(irValue)
+	if err != nil {
+		return nil, fmt.Errorf("for member '%s': %w", memberName, err)
+	}
+
+	if kind != token.INT && kind != token.FLOAT {
+		return nil, fmt.Errorf("type error for member '%s': float enum expects INT or FLOAT literal, got %v", memberName, kind)
+	}
+
+	val, err := strconv.ParseFloat(numericStr, t.BitSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid numeric literal for member '%s': cannot parse '%s' as %s", memberName, numericStr, t.Name)
+	}
+
+	return val, nil
+}
+
+// numericString returns the source text of a possibly negated numeric literal
+// and the kind of the literal.
+func numericString(irValue compiler.IRValue) (string, token.Token, error) {
+	switch v := irValue.(type) {
+	case compiler.IRLiteral:
+		return v.Value(), v.Kind(), nil
+	case compiler.IRUnary:
+		if v.Operator() != token.SUB {
+			return "", token.ILLEGAL, fmt.Errorf("type error: unsupported unary operator %v", v.Operator())
+		}
+		operand, kind, err := numericString(v.Operand())
+		if err != nil {
+			return "", token.ILLEGAL, err
+		}
+		return "-" + operand, kind, nil
+	default:
+		return "", token.ILLEGAL, fmt.Errorf("internal error: expected IRLiteral or IRUnary, got %T", irValue)
+	}
+}
+
+func decodeBool(irValue compiler.IRValue, memberName string) (any, error) {
+	if irValue == nil {
+		return false, nil
+	}
+
+	literal, ok := irValue.(compiler.IRLiteral)
+	if !ok {
+		return nil, fmt.Errorf("internal error: expected IRLiteral for bool handler, got %T", irValue)
+	}
+
+	switch kind := literal.Kind(); kind {
+	case token.TRUE:
+		return true, nil
+	case token.FALSE:
+		return false, nil
+	default:
+		pos := literal.Position()
+		return nil, fmt.Errorf(
+			"type error at %s member '%s' expects a boolean (true or false), but got %v",
+			pos.String(),
+			memberName,
+			kind,
+		)
+	}
+}
@@ -0,0 +1,79 @@
+// Package primitive describes the primitive types of EDL and decodes their
+// literals into Go values. It knows nothing of the languages generated from
+// EDL, so every generator can build on it.
+package primitive
+
+import "fmt"
+
+type Kind int
+
+const (
+	Invalid Kind = iota
+	String
+	Char
+	Int // signed and unsigned integers
+	Float
+	Bool
+)
+
+func (k Kind) String() string {
+	switch k {
+	case String:
+		return "string"
+	case Char:
+		return "char"
+	case Int:
+		return "integer"
+	case Float:
+		return "float"
+	case Bool:
+		return "bool"
+	default:
+		return "invalid"
+	}
+}
+
+// Type is a primitive type of EDL.
+type Type struct {
+	Name     string // canonical name, e.g. int32 for rune and float32 for float
+	Kind     Kind
+	BitSize  int  // size of integers and floats
+	Unsigned bool // whether an integer is unsigned
+}
+
+var types = map[string]Type{
+	"char":    {Name: "char", Kind: Char},
+	"string":  {Name: "string", Kind: String},
+	"int":     {Name: "int", Kind: Int, BitSize: 64},
+	"int8":    {Name: "int8", Kind: Int, BitSize: 8},
+	"int16":   {Name: "int16", Kind: Int, BitSize: 16},
+	"int32":   {Name: "int32", Kind: Int, BitSize: 32},
+	"rune":    {Name: "int32", Kind: Int, BitSize: 32},
+	"int64":   {Name: "int64", Kind: Int, BitSize: 64},
+	"uint":    {Name: "uint", Kind: Int, BitSize: 64, Unsigned: true},
+	"uint8":   {Name: "uint8", Kind: Int, BitSize: 8, Unsigned: true},
+	"byte":    {Name: "uint8", Kind: Int, BitSize: 8, Unsigned: true},
+	"uint16":  {Name: "uint16", Kind: Int, BitSize: 16, Unsigned: true},
+	"uint32":  {Name: "uint32", Kind: Int, BitSize: 32, Unsigned: true},
+	"uint64":  {Name: "uint64", Kind: Int, BitSize: 64, Unsigned: true},
+	"float":   {Name: "float32", Kind: Float, BitSize: 32},
+	"float32": {Name: "float32", Kind: Float, BitSize: 32},
+	"float64": {Name: "float64", Kind: Float, BitSize: 64},
+	"bool":    {Name: "bool", Kind: Bool},
+}
+
+// Lookup returns the primitive type with the given name or alias.
+func Lookup(name string) (Type, bool) {
+	t, ok := types[name]
+	return t, ok
+}
+
+// MustLookup is like Lookup but panics if name is not a primitive type. It
+// is meant for the type tables generators declare.
+func MustLookup(name string) Type {
+	t, ok := types[name]
+	if !ok {
+		panic(fmt.Sprintf("primitive: unknown type %q", name))
+	}
+	return t
+}
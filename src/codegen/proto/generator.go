@@ -0,0 +1,388 @@
+package proto
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"math"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/kkumar-gcc/enumgen/pkg/strcase"
+	"github.com/kkumar-gcc/enumgen/src/codegen/contracts"
+	"github.com/kkumar-gcc/enumgen/src/codegen/primitive"
+	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
+	"github.com/kkumar-gcc/enumgen/src/version"
+)
+
+var _ contracts.Generator = (*Generator)(nil)
+
+// fileNameSource is replaced by the EDL file name without its extension in
+// file name patterns.
+const fileNameSource = "{file}"
+
+// optionsFileName is the name of the file declaring the extensions, which is
+// generated next to the enums.
+const optionsFileName = "enumgen_options.proto"
+
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type Generator struct {
+	file       *template.Template
+	options    *template.Template
+	primitives map[string]primitive.Type
+}
+
+func New() (*Generator, error) {
+	file, err := loadTemplate("file", fileTemplate, templatesFS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load templates: %w", err)
+	}
+	options, err := loadTemplate("options", optionsTemplate, templatesFS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	return &Generator{
+		file:       file,
+		options:    options,
+		primitives: defaultPrimitives(),
+	}, nil
+}
+
+func (g *Generator) Name() string {
+	return "Protocol Buffers"
+}
+
+func (g *Generator) Language() string {
+	return "proto"
+}
+
+func (g *Generator) DefaultOptions() map[string]string {
+	return defaultOptions
+}
+
+func (g *Generator) OptionHelp() string {
+	sb := strings.Builder{}
+	sb.WriteString("Available options for " + g.Name() + " code generation:\n")
+	for key, value := range g.DefaultOptions() {
+		help := optionHelp[key]
+		if help == "" {
+			sb.WriteString(fmt.Sprintf("  - %s (default: %s)\n", key, value))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("  - %s: %s (default: %s)\n", key, help, value))
+	}
+	return sb.String()
+}
+
+func (g *Generator) Generate(module compiler.IRModule, options map[string]string) ([]*compiler.OutputFile, error) {
+	opts := maps.Clone(g.DefaultOptions())
+	maps.Copy(opts, options)
+
+	pkg := opts[OptionPackage]
+	for _, part := range strings.Split(pkg, ".") {
+		if !identifier.MatchString(part) {
+			return nil, fmt.Errorf("invalid protobuf package name '%s'", pkg)
+		}
+	}
+	allowAlias, err := strconv.ParseBool(opts[OptionAllowAlias])
+	if err != nil {
+		return nil, fmt.Errorf("invalid value '%s' for option '%s': %w", opts[OptionAllowAlias], OptionAllowAlias, err)
+	}
+	// Extensions of a single organization take field numbers from 50000 to
+	// 99999, below the range reserved by protobuf itself.
+	optionNumber, err := strconv.Atoi(opts[OptionOptionNumber])
+	if err != nil || optionNumber < 1 || (optionNumber >= 18999 && optionNumber <= 19999) || optionNumber >= 1<<29-1 {
+		return nil, fmt.Errorf("invalid value '%s' for option '%s': expected a free field number such as 50000", opts[OptionOptionNumber], OptionOptionNumber)
+	}
+
+	if len(module.Enums()) == 0 {
+		return nil, nil
+	}
+
+	data := FileData{
+		EDLVersion:  version.Version,
+		ToolVersion: Version,
+		Doc:         module.Doc(),
+		Package:     pkg,
+		GoPackage:   escape(opts[OptionGoPackage]),
+	}
+
+	// Enum values are scoped like their enums, so they share the namespace of
+	// the package across enums.
+	owners := make(map[string]string)
+	var hasKeys, hasValues bool
+	for _, enum := range module.Enums() {
+		enumData, err := g.prepareTemplateData(enum, allowAlias, owners)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate code for enum '%s': failed to prepare data: %w", enum.Name(), err)
+		}
+		for _, v := range enumData.Values {
+			for _, o := range v.Options {
+				hasKeys = hasKeys || strings.HasPrefix(o, "(enum_key)")
+				hasValues = hasValues || strings.HasPrefix(o, "(enum_value)")
+			}
+		}
+		data.Enums = append(data.Enums, *enumData)
+	}
+
+	source := filepath.Base(module.Name())
+	source = strings.ToLower(strings.TrimSuffix(source, filepath.Ext(source)))
+	fileName := strings.ReplaceAll(opts[OptionFileName], fileNameSource, source)
+
+	var files []*compiler.OutputFile
+	if hasKeys || hasValues {
+		// Extensions are declared once per package, so the options file is
+		// the same for every EDL file and can be overwritten by each of them.
+		optionsPath := filepath.Join(filepath.Dir(fileName), optionsFileName)
+		if optionsPath == filepath.Clean(fileName) {
+			return nil, fmt.Errorf("file name '%s' is reserved for the extensions enum values carry data in", fileName)
+		}
+		code, err := render(g.options, OptionsData{
+			EDLVersion:  data.EDLVersion,
+			ToolVersion: data.ToolVersion,
+			Package:     data.Package,
+			GoPackage:   data.GoPackage,
+			KeyNumber:   strconv.Itoa(optionNumber),
+			ValueNumber: strconv.Itoa(optionNumber + 1),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate file '%s': %w", optionsPath, err)
+		}
+		files = append(files, &compiler.OutputFile{Path: optionsPath, Body: code})
+		data.OptionsImport = filepath.ToSlash(optionsPath)
+	}
+
+	code, err := render(g.file, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate file '%s': %w", fileName, err)
+	}
+	return append(files, &compiler.OutputFile{Path: fileName, Body: code}), nil
+}
+
+// render executes tmpl with data and ends the result with a single newline.
+func render(tmpl *template.Template, data any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+	return append(bytes.TrimRight(buf.Bytes(), "\n"), '\n'), nil
+}
+
+func (g *Generator) prepareTemplateData(enum compiler.IREnumDefinition, allowAlias bool, owners map[string]string) (*TemplateData, error) {
+	prefix := constName(enum.Name()) + "_"
+	data := &TemplateData{
+		EnumName:   enum.Name(),
+		EnumDoc:    enum.Doc(),
+		Deprecated: deprecated(enum.Annotations()),
+	}
+
+	// claim prefixes name and records owner as its source, a description such
+	// as "member 'RED' of enum 'Color'".
+	claim := func(name, owner string) (string, error) {
+		if !strings.HasPrefix(name, prefix) {
+			name = prefix + name
+		}
+		if prev, ok := owners[name]; ok {
+			return "", fmt.Errorf("%s and %s both map to '%s'", prev, owner, name)
+		}
+		owners[name] = owner
+		return name, nil
+	}
+	describe := func(name string) string {
+		return fmt.Sprintf("member '%s' of enum '%s'", name, enum.Name())
+	}
+
+	unspecified, err := claim("UNSPECIFIED", fmt.Sprintf("the zero value of enum '%s'", enum.Name()))
+	if err != nil {
+		return nil, err
+	}
+	data.Values = append(data.Values, TemplateValue{Name: unspecified, Number: "0"})
+
+	key, value, numbered := g.memberData(enum)
+	numbers := map[int64]string{0: unspecified}
+	values := make(map[string]TemplateValue, len(enum.Members()))
+	for i, member := range enum.Members() {
+		name, err := claim(constName(member.Name()), describe(member.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		var keyIR, valueIR compiler.IRValue
+		switch v := member.Value().(type) {
+		case compiler.IRKeyValue:
+			keyIR, valueIR = referencedValue(v.Key()), referencedValue(v.Value())
+		case compiler.IRTuple:
+		default:
+			keyIR = referencedValue(member.Value())
+			valueIR = keyIR
+		}
+
+		// Members without an integer to keep are numbered in declaration order.
+		number := int64(i + 1)
+		var options []string
+		if deprecated(member.Annotations()) {
+			options = append(options, "deprecated = true")
+		}
+		for _, d := range []struct {
+			primitive primitive.Type
+			ir        compiler.IRValue
+			option    string
+		}{{key, keyIR, "enum_key"}, {value, valueIR, "enum_value"}} {
+			if d.primitive.Kind == primitive.Invalid {
+				continue
+			}
+			v, err := d.primitive.Decode(d.ir, member.Name(), i)
+			if err != nil {
+				return nil, fmt.Errorf("error formatting %s of member '%s': %w", strings.TrimPrefix(d.option, "enum_"), member.Name(), err)
+			}
+			if d.option == numbered {
+				if number, err = int32Number(v); err != nil {
+					return nil, fmt.Errorf("member '%s': %w", member.Name(), err)
+				}
+				continue
+			}
+			options = append(options, fmt.Sprintf("(%s) = \"%s\"", d.option, escape(text(v))))
+		}
+
+		if prev, ok := numbers[number]; ok {
+			if !allowAlias {
+				return nil, fmt.Errorf("member '%s' has the number %d of '%s', but aliases are not allowed", member.Name(), number, prev)
+			}
+			data.AllowAlias = true
+		} else {
+			numbers[number] = name
+		}
+
+		values[member.Name()] = TemplateValue{
+			Name:    name,
+			Number:  strconv.FormatInt(number, 10),
+			Doc:     member.Doc(),
+			Options: options,
+		}
+		data.Values = append(data.Values, values[member.Name()])
+	}
+
+	// Members assigned another member become aliases of its value.
+	for _, member := range enum.MemberAliases() {
+		ref, ok := member.Value().(compiler.IRReference)
+		if !ok {
+			return nil, fmt.Errorf("member '%s' is not assigned another member", member.Name())
+		}
+		target, ok := values[ref.Member()]
+		if !ok {
+			return nil, fmt.Errorf("member '%s' refers to unknown member '%s'", member.Name(), ref.Member())
+		}
+		name, err := claim(constName(member.Name()), describe(member.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		var options []string
+		if deprecated(member.Annotations()) {
+			options = append(options, "deprecated = true")
+		}
+		data.Values = append(data.Values, TemplateValue{
+			Name:    name,
+			Number:  target.Number,
+			Doc:     member.Doc(),
+			Options: options,
+		})
+		data.AllowAlias = true
+	}
+
+	return data, nil
+}
+
+// memberData returns the primitive types of the key and value of the members
+// of enum that the generated values carry, the zero Type for those that are
+// not carried, and which of them, "enum_key" or "enum_value", is kept as the
+// number. Only integers can be numbers, and only strings and characters are
+// carried as options, so other keys and values are dropped.
+func (g *Generator) memberData(enum compiler.IREnumDefinition) (key, value primitive.Type, numbered string) {
+	if len(enum.Fields()) > 0 || enum.ValueType() == nil {
+		return primitive.Type{}, primitive.Type{}, ""
+	}
+
+	if enum.KeyType() == nil {
+		key = g.primitives[enum.ValueType().String()]
+		if key.Kind == primitive.Int {
+			numbered = "enum_key"
+		}
+		return key, primitive.Type{}, numbered
+	}
+
+	key = g.primitives[enum.KeyType().String()]
+	value = g.primitives[enum.ValueType().String()]
+	if key.Kind == primitive.Int {
+		numbered = "enum_key"
+	} else if value.Kind == primitive.Int {
+		numbered = "enum_value"
+	}
+	// Integers that are not kept as the number are not carried either.
+	if key.Kind == primitive.Int && numbered != "enum_key" {
+		key = primitive.Type{}
+	}
+	if value.Kind == primitive.Int && numbered != "enum_value" {
+		value = primitive.Type{}
+	}
+	return key, value, numbered
+}
+
+// int32Number returns a decoded integer as the number of an enum value.
+func int32Number(v any) (int64, error) {
+	var n int64
+	switch v := v.(type) {
+	case int64:
+		n = v
+	case uint64:
+		if v > math.MaxInt32 {
+			return 0, fmt.Errorf("number %d does not fit in int32", v)
+		}
+		n = int64(v)
+	default:
+		return 0, fmt.Errorf("internal error: unsupported number %#v", v)
+	}
+	if n < math.MinInt32 || n > math.MaxInt32 {
+		return 0, fmt.Errorf("number %d does not fit in int32", n)
+	}
+	return n, nil
+}
+
+// text returns a decoded string or character as a string.
+func text(v any) string {
+	if r, ok := v.(rune); ok {
+		return string(r)
+	}
+	return fmt.Sprint(v)
+}
+
+// constName returns the name of an enum or member in UPPER_SNAKE_CASE, as
+// protobuf enum values are named.
+func constName(name string) string {
+	return strings.ToUpper(strcase.ToSnake(name))
+}
+
+// referencedValue returns the value a member reference stands for, or v
+// itself if it is not a reference.
+func referencedValue(v compiler.IRValue) compiler.IRValue {
+	if ref, ok := v.(compiler.IRReference); ok {
+		return ref.Target()
+	}
+	return v
+}
+
+// deprecated reports whether annotations declare @deprecated. Protobuf has
+// no place for the deprecation notice.
+func deprecated(annotations []compiler.IRAnnotation) bool {
+	for _, a := range annotations {
+		if a.Name() == "deprecated" {
+			return true
+		}
+	}
+	return false
+}
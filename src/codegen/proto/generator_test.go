@@ -0,0 +1,227 @@
+package proto_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kkumar-gcc/enumgen/src/codegen/internal/testutil"
+)
+
+// sampleSource adds a tuple enum and an enum keyed by booleans with a quote
+// for a value, which protobuf can only number.
+const sampleSource = testutil.SampleSource + `
+enum HTTPStatus [code int32 unique, text string]:
+    OK = (200, "OK"),
+    UNAVAILABLE = (503, "Service Unavailable");
+
+enum Answer [bool, char]:
+    YES = true:'y',
+    NO = false:'"';
+`
+
+func TestGenerate(t *testing.T) {
+	files := testutil.Generate(t, "proto", sampleSource, nil)
+	code, ok := files["enums.proto"]
+	if !ok || len(files) != 2 {
+		t.Fatalf("expected enums.proto and enumgen_options.proto, got %v", files)
+	}
+
+	testutil.AssertContains(t, files["enumgen_options.proto"],
+		"syntax = \"proto3\";\n\npackage enums;\n\nimport \"google/protobuf/descriptor.proto\";\n",
+		"extend google.protobuf.EnumValueOptions {\n  // The key of the member.\n  string enum_key = 50000;\n  // The value of the member.\n  string enum_value = 50001;\n}",
+	)
+	testutil.AssertContains(t, code,
+		"// Enums shared with the services.\n\nsyntax = \"proto3\";\n\npackage enums;\n\nimport \"enumgen_options.proto\";\n",
+		"// Status of an operation.\nenum Status {\n  option allow_alias = true;\n  STATUS_UNSPECIFIED = 0;\n  STATUS_SUCCESS = 1;\n",
+		"  STATUS_FAILURE = 3;\n}",
+		"  COLOR_RED = 1 [(enum_key) = \"red\"];",
+		"  COLOR_GREEN = 2 [deprecated = true, (enum_key) = \"green\"];",
+		"  DAY_TUESDAY = 2 [(enum_key) = \"Tuesday\"];",
+		"  HTTP_STATUS_UNAVAILABLE = 2;",
+		"enum Perm {\n  PERM_UNSPECIFIED = 0;\n  PERM_READ = 1;\n  PERM_WRITE = 2;\n  PERM_EXEC = 4;\n}",
+		"  ANSWER_NO = 2 [(enum_value) = \"\\\"\"];",
+	)
+	if strings.Contains(code, "extend") {
+		t.Errorf("extensions should only be declared in enumgen_options.proto")
+	}
+	if strings.Contains(code, "option go_package") {
+		t.Errorf("go_package should only be set on request")
+	}
+	if strings.Count(code, "allow_alias") != 1 {
+		t.Errorf("only enums with aliases should allow them")
+	}
+}
+
+func TestGenerateAliases(t *testing.T) {
+	files := testutil.Generate(t, "proto", testutil.AliasSource, nil)
+	code := files["enums.proto"]
+	testutil.AssertContains(t, code,
+		"enum Mode {\n  option allow_alias = true;\n  MODE_UNSPECIFIED = 0;\n  MODE_READ = 1;\n  MODE_WRITE = 2;\n  MODE_EXEC = 4;\n  MODE_RUN = 4;\n}",
+		"enum Point {\n  option allow_alias = true;\n  POINT_UNSPECIFIED = 0;\n  POINT_ORIGIN = 1;\n  POINT_UNIT = 2;\n  POINT_ZERO = 1;\n}",
+	)
+	if len(files) != 1 || strings.Contains(code, "import") {
+		t.Errorf("key aliases and tuple fields should not need extensions:\n%s", code)
+	}
+}
+
+func TestGenerateCompiles(t *testing.T) {
+	protoc := testutil.LookPath(t, "protoc")
+
+	dir := t.TempDir()
+	files := testutil.Generate(t, "proto", sampleSource+testutil.AliasSource, map[string]string{
+		"package":    "acme.enums.v1",
+		"go_package": "example.com/acme/enums",
+	})
+	testutil.WriteFiles(t, dir, files)
+	testutil.WriteFiles(t, dir, testutil.Generate(t, "proto", sharedSource, map[string]string{
+		"package":    "acme.enums.v1",
+		"go_package": "example.com/acme/enums",
+		"file_name":  "more.proto",
+	}))
+	testutil.Run(t, dir, protoc, "--proto_path=.", "--descriptor_set_out="+filepath.Join(dir, "enums.pb"), "enums.proto", "more.proto")
+}
+
+// sharedSource carries keys and values like sampleSource, as a second EDL
+// file generated into the same package.
+const sharedSource = `
+enum Size [string, string]:
+    SMALL = "s":"small",
+    LARGE = "l":"large";
+`
+
+func TestGenerateSharedOptions(t *testing.T) {
+	files := testutil.Generate(t, "proto", sampleSource, nil)
+	more := testutil.Generate(t, "proto", sharedSource, map[string]string{"file_name": "more.proto"})
+	if files["enumgen_options.proto"] != more["enumgen_options.proto"] {
+		t.Fatalf("EDL files generated into the same package should share the extensions:\n%s\n%s", files["enumgen_options.proto"], more["enumgen_options.proto"])
+	}
+	testutil.AssertContains(t, more["more.proto"], "import \"enumgen_options.proto\";\n", "  SIZE_SMALL = 1 [(enum_key) = \"s\", (enum_value) = \"small\"];")
+	if strings.Contains(more["more.proto"], "extend") {
+		t.Errorf("more.proto should not declare the extensions itself")
+	}
+
+	ctx, err := testutil.Compile(t, "proto", sharedSource, map[string]string{"file_name": "enumgen_options.proto"})
+	if err == nil && len(ctx.Errors) == 0 {
+		t.Errorf("expected the name of the options file to be reserved")
+	}
+}
+
+func TestGenerateOptions(t *testing.T) {
+	files := testutil.Generate(t, "proto", sampleSource, map[string]string{
+		"package":       "acme.enums.v1",
+		"go_package":    "example.com/acme/enums",
+		"option_number": "51000",
+		"file_name":     "acme/{file}.proto",
+	})
+
+	code, ok := files["acme/enums.proto"]
+	if !ok {
+		t.Fatalf("expected the file name pattern to be used, got %v", files)
+	}
+	testutil.AssertContains(t, code,
+		"package acme.enums.v1;",
+		"import \"acme/enumgen_options.proto\";",
+		"option go_package = \"example.com/acme/enums\";",
+	)
+	testutil.AssertContains(t, files["acme/enumgen_options.proto"],
+		"package acme.enums.v1;",
+		"option go_package = \"example.com/acme/enums\";",
+		"  string enum_key = 51000;",
+		"  string enum_value = 51001;",
+	)
+
+	files = testutil.Generate(t, "proto", "enum Level [int]:\n    LOW = 1,\n    HIGH = 2;\n", nil)
+	if code := files["enums.proto"]; len(files) != 1 || strings.Contains(code, "import") {
+		t.Errorf("extensions should only be declared when values carry data:\n%s", code)
+	}
+}
+
+func TestGenerateNumbers(t *testing.T) {
+	tests := map[string]struct {
+		source  string
+		message string
+	}{
+		"zero": {
+			source:  "enum Status [int]:\n    SUCCESS = 0,\n    ERROR = 1;\n",
+			message: "number 0 of member SUCCESS is already held by STATUS_UNSPECIFIED",
+		},
+		"implicit zero": {
+			source:  "enum Level [uint8]:\n    LOW,\n    HIGH;\n",
+			message: "number 0 of member LOW is already held by LEVEL_UNSPECIFIED",
+		},
+		"negative": {
+			source:  "enum Offset [int]:\n    BACK = -1,\n    FORWARD = 1;\n",
+			message: "number -1 of member BACK is negative",
+		},
+		"beyond int32": {
+			source:  "enum Size [int64]:\n    HUGE = 4294967296;\n",
+			message: "number 4294967296 of member HUGE does not fit in a protobuf enum",
+		},
+		"default type": {
+			source:  "option default_type = int;\n\nenum Level:\n    LOW,\n    HIGH;\n",
+			message: "number 0 of member LOW is already held by LEVEL_UNSPECIFIED",
+		},
+		"duplicate value": {
+			source:  "enum Day [string, int]:\n    SATURDAY = \"Sat\":6,\n    WEEKEND = \"Weekend\":6;\n",
+			message: "number 6 of member WEEKEND is already held by SATURDAY",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := testutil.Compile(t, "proto", tt.source, nil)
+			if !strings.Contains(ctx.Validations.String(), tt.message) {
+				t.Fatalf("expected %q, got:\n%s", tt.message, ctx.Validations.String())
+			}
+		})
+	}
+
+	code := testutil.Generate(t, "proto", tests["zero"].source, map[string]string{"allow_alias": "true"})["enums.proto"]
+	testutil.AssertContains(t, code, "  option allow_alias = true;\n  STATUS_UNSPECIFIED = 0;\n  STATUS_SUCCESS = 0;\n")
+
+	// Other generators are not bound by protobuf numbering.
+	ctx, err := testutil.Compile(t, "go", tests["negative"].source, nil)
+	if err != nil || ctx.Validations.HasErrors() {
+		t.Fatalf("expected the Go generator to accept negative numbers: %v\n%s", err, ctx.Validations.String())
+	}
+}
+
+func TestGenerateInvalid(t *testing.T) {
+	tests := map[string]string{
+		"colliding members": `
+enum Mode [int]:
+    READ_ONLY = 1,
+    ReadOnly = 2;
+`,
+		"unspecified member": `
+enum Mode [string]:
+    UNSPECIFIED = "none";
+`,
+		"colliding enums": `
+enum HTTPStatus [string]:
+    OK = "ok";
+
+enum HttpStatus [string]:
+    OK = "ok";
+`,
+	}
+	for name, source := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, err := testutil.Compile(t, "proto", source, nil)
+			if err == nil && len(ctx.Errors) == 0 && !ctx.Validations.HasErrors() {
+				t.Fatalf("expected the enum to be rejected")
+			}
+		})
+	}
+
+	for option, value := range map[string]string{
+		"package":       "acme-enums",
+		"allow_alias":   "maybe",
+		"option_number": "19500",
+	} {
+		ctx, err := testutil.Compile(t, "proto", sampleSource, map[string]string{option: value})
+		if err == nil && len(ctx.Errors) == 0 {
+			t.Errorf("expected an invalid %s option to be rejected", option)
+		}
+	}
+}
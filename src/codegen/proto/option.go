@@ -0,0 +1,60 @@
+package proto
+
+const (
+	OptionPackage      = "package"
+	OptionGoPackage    = "go_package"
+	OptionAllowAlias   = "allow_alias"
+	OptionOptionNumber = "option_number"
+	OptionFileName     = "file_name"
+)
+
+type OptionDef struct {
+	Key          string
+	DefaultValue string
+	HelpText     string
+}
+
+var allOptions = []OptionDef{
+	{
+		Key:          OptionPackage,
+		DefaultValue: "enums",
+		HelpText:     "The protobuf package of the generated enums.",
+	},
+	{
+		Key:          OptionGoPackage,
+		DefaultValue: "",
+		HelpText:     "If set, the go_package option of the generated file.",
+	},
+	{
+		Key:          OptionAllowAlias,
+		DefaultValue: "false",
+		HelpText:     "If true, members may share numbers, including 0 with the <ENUM>_UNSPECIFIED value, and become aliases with 'option allow_alias = true'.",
+	},
+	{
+		Key:          OptionOptionNumber,
+		DefaultValue: "50000",
+		HelpText:     "The field number of the enum_key extension of EnumValueOptions; enum_value takes the next one.",
+	},
+	{
+		Key:          OptionFileName,
+		DefaultValue: "{file}.proto",
+		HelpText:     "The generated file name pattern; {file} is the EDL file name.",
+	},
+}
+
+var (
+	defaultOptions map[string]string
+	optionHelp     map[string]string
+)
+
+func init() {
+	defaultOptions = make(map[string]string)
+	optionHelp = make(map[string]string)
+
+	for _, opt := range allOptions {
+		defaultOptions[opt.Key] = opt.DefaultValue
+		if opt.HelpText != "" {
+			optionHelp[opt.Key] = opt.HelpText
+		}
+	}
+}
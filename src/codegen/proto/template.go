@@ -0,0 +1,98 @@
+package proto
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// fileTemplate renders a complete proto3 file with the enums of an EDL file.
+const fileTemplate = "templates/file.proto.tmpl"
+
+// optionsTemplate renders the file declaring the extensions enum values carry
+// data in. It only depends on the package and option numbers, so every EDL
+// file generated into a package shares it.
+const optionsTemplate = "templates/options.proto.tmpl"
+
+var templateFuncs = template.FuncMap{
+	"comment": comment,
+	"join":    strings.Join,
+}
+
+// comment renders text as line comments. Every line after the first is
+// prefixed with indent so the block lines up with the first line.
+func comment(indent, text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = "//"
+		} else {
+			lines[i] = "// " + line
+		}
+		if i > 0 {
+			lines[i] = indent + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// TemplateValue is a value of a protobuf enum.
+type TemplateValue struct {
+	Name    string // prefixed with the enum name, e.g. STATUS_SUCCESS
+	Number  string
+	Doc     string
+	Options []string // field options, e.g. deprecated = true or (enum_key) = "red"
+}
+
+type TemplateData struct {
+	EnumName   string
+	EnumDoc    string
+	Deprecated bool
+	AllowAlias bool // whether values share numbers
+	Values     []TemplateValue
+}
+
+// FileData holds everything that appears once per generated file.
+type FileData struct {
+	EDLVersion  string
+	ToolVersion string
+	Doc         string
+	Package     string
+	GoPackage   string // escaped for use in a string literal
+	Enums       []TemplateData
+
+	// OptionsImport is the path of the file declaring the enum_key and
+	// enum_value extensions, or "" if no value carries a key or value.
+	OptionsImport string
+}
+
+// OptionsData holds the content of the file declaring the extensions.
+type OptionsData struct {
+	EDLVersion  string
+	ToolVersion string
+	Package     string
+	GoPackage   string // escaped for use in a string literal
+
+	// KeyNumber and ValueNumber are the field numbers of the enum_key and
+	// enum_value extensions.
+	KeyNumber   string
+	ValueNumber string
+}
+
+func loadTemplate(name, path string, fs embed.FS) (*template.Template, error) {
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", path, err)
+	}
+
+	return tmpl, nil
+}
@@ -0,0 +1,47 @@
+package proto
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/kkumar-gcc/enumgen/src/codegen/primitive"
+)
+
+// defaultPrimitives maps the primitives of the EDL type registry that enum
+// values can carry to the primitive types decoding their literals. Integers
+// become numbers, and strings and characters options; other types are
+// dropped.
+func defaultPrimitives() map[string]primitive.Type {
+	primitives := make(map[string]primitive.Type)
+	for _, name := range []string{"char", "string", "int", "int8", "int32", "int64", "uint", "uint8", "uint32", "uint64"} {
+		primitives[name] = primitive.MustLookup(name)
+	}
+	return primitives
+}
+
+// escape escapes s for a double-quoted protobuf string literal. Characters
+// that are not printable are written as octal escapes of their UTF-8 bytes.
+func escape(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			sb.WriteRune('\\')
+			sb.WriteRune(r)
+		case r == '\n':
+			sb.WriteString(`\n`)
+		case r == '\r':
+			sb.WriteString(`\r`)
+		case r == '\t':
+			sb.WriteString(`\t`)
+		case unicode.IsPrint(r):
+			sb.WriteRune(r)
+		default:
+			for _, b := range []byte(string(r)) {
+				fmt.Fprintf(&sb, `\%03o`, b)
+			}
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,5 @@
+package proto
+
+const (
+	Version = "v0.0.1"
+)
@@ -0,0 +1,544 @@
+package python
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/kkumar-gcc/enumgen/pkg/strcase"
+	"github.com/kkumar-gcc/enumgen/src/codegen/contracts"
+	"github.com/kkumar-gcc/enumgen/src/codegen/output"
+	"github.com/kkumar-gcc/enumgen/src/codegen/primitive"
+	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
+	"github.com/kkumar-gcc/enumgen/src/version"
+)
+
+var _ contracts.Generator = (*Generator)(nil)
+
+// keywords are the reserved words of Python, which cannot name classes,
+// members or parameters.
+var keywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true, "assert": true,
+	"async": true, "await": true, "break": true, "class": true, "continue": true,
+	"def": true, "del": true, "elif": true, "else": true, "except": true,
+	"finally": true, "for": true, "from": true, "global": true, "if": true,
+	"import": true, "in": true, "is": true, "lambda": true, "nonlocal": true,
+	"not": true, "or": true, "pass": true, "raise": true, "return": true,
+	"try": true, "while": true, "with": true, "yield": true,
+}
+
+// reservedAttributes are attributes of generated enums that members and field
+// properties must not replace.
+var reservedAttributes = map[string]bool{
+	"name": true, "value": true, "key": true, "raw_value": true,
+	"from_key": true, "from_value": true,
+}
+
+type Generator struct {
+	enum       *template.Template
+	file       *template.Template
+	valueTypes map[string]valueType
+}
+
+func New() (*Generator, error) {
+	enum, err := loadTemplate("enum", enumTemplate, templatesFS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load templates: %w", err)
+	}
+	file, err := loadTemplate("file", fileTemplate, templatesFS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	return &Generator{
+		enum:       enum,
+		file:       file,
+		valueTypes: defaultValueTypes(),
+	}, nil
+}
+
+func (g *Generator) Name() string {
+	return "Python"
+}
+
+func (g *Generator) Language() string {
+	return "python"
+}
+
+func (g *Generator) DefaultOptions() map[string]string {
+	return defaultOptions
+}
+
+func (g *Generator) OptionHelp() string {
+	sb := strings.Builder{}
+	sb.WriteString("Available options for " + g.Name() + " code generation:\n")
+	for key, value := range g.DefaultOptions() {
+		help := optionHelp[key]
+		if help == "" {
+			sb.WriteString(fmt.Sprintf("  - %s (default: %s)\n", key, value))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("  - %s: %s (default: %s)\n", key, help, value))
+	}
+	return sb.String()
+}
+
+func (g *Generator) Generate(module compiler.IRModule, options map[string]string) ([]*compiler.OutputFile, error) {
+	opts := maps.Clone(g.DefaultOptions())
+	maps.Copy(opts, options)
+
+	outputMode, pattern, err := output.Resolve(opts[OptionOutputMode], opts[OptionFileName], ".py")
+	if err != nil {
+		return nil, err
+	}
+
+	enums := make(map[string]string, len(module.Enums()))
+	for _, enum := range module.Enums() {
+		code, err := g.generateEnum(enum)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate code for enum '%s': %w", enum.Name(), err)
+		}
+		enums[enum.Name()] = code
+	}
+
+	if outputMode == output.Single {
+		if len(enums) == 0 {
+			return nil, nil
+		}
+
+		// Members of tuple enums hold members of the enums their fields refer
+		// to, so those classes have to be defined first.
+		ordered := orderEnums(module.Enums())
+		bodies := make([]string, len(ordered))
+		for i, enum := range ordered {
+			bodies[i] = enums[enum.Name()]
+		}
+
+		fileName := output.FileName(pattern, module.Name(), "")
+		code, err := g.generateFile(module.Doc(), nil, bodies)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate file '%s': %w", fileName, err)
+		}
+		return []*compiler.OutputFile{{Path: fileName, Body: code}}, nil
+	}
+
+	fileNames, err := output.FileNames(pattern, module)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*compiler.OutputFile, 0, len(enums))
+	for _, enum := range module.Enums() {
+		fileName := fileNames[enum.Name()]
+
+		var imports []Import
+		for _, dep := range dependencies(enum) {
+			path := filepath.Base(fileNames[dep])
+			imports = append(imports, Import{
+				Module: "." + strings.TrimSuffix(path, filepath.Ext(path)),
+				Names:  []string{dep},
+			})
+		}
+
+		// The package doc describes the module as a whole rather than any one
+		// enum, so it is only written to the single file.
+		code, err := g.generateFile("", imports, []string{enums[enum.Name()]})
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate code for enum '%s': %w", enum.Name(), err)
+		}
+
+		files = append(files, &compiler.OutputFile{
+			Path: fileName,
+			Body: code,
+		})
+	}
+
+	return files, nil
+}
+
+// generateFile wraps rendered enums with the module header and imports.
+func (g *Generator) generateFile(doc string, imports []Import, enums []string) ([]byte, error) {
+	data := FileData{
+		EDLVersion:  version.Version,
+		ToolVersion: Version,
+		Doc:         doc,
+		Imports:     imports,
+		Enums:       enums,
+	}
+
+	var buf bytes.Buffer
+	if err := g.file.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return tidySource(buf.Bytes()), nil
+}
+
+var blankLines = regexp.MustCompile(`\n{4,}`)
+
+// tidySource collapses runs of more than two blank lines left by the templates
+// and ends the source with a single newline.
+func tidySource(src []byte) []byte {
+	src = blankLines.ReplaceAll(src, []byte("\n\n\n"))
+	return append(bytes.TrimRight(src, "\n"), '\n')
+}
+
+// generateEnum renders the class of a single enum and its lookup tables.
+func (g *Generator) generateEnum(enum compiler.IREnumDefinition) (string, error) {
+	data, err := g.prepareTemplateData(enum)
+	if err != nil {
+		return "", fmt.Errorf("failed to prepare data: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := g.enum.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+func (g *Generator) prepareTemplateData(enum compiler.IREnumDefinition) (*TemplateData, error) {
+	if keywords[enum.Name()] {
+		return nil, fmt.Errorf("enum '%s' cannot be named after a Python keyword", enum.Name())
+	}
+
+	fields, err := g.prepareFields(enum)
+	if err != nil {
+		return nil, err
+	}
+
+	valueTypeName := "string" // tuple members are keyed by their names
+	if fields == nil {
+		valueType := enum.ValueType()
+		if valueType == nil {
+			return nil, fmt.Errorf("enum '%s' has no value type defined", enum.Name())
+		}
+		valueTypeName = valueType.String()
+	}
+	valueType, ok := g.valueTypes[valueTypeName]
+	if !ok {
+		return nil, fmt.Errorf("unsupported value type '%s' for enum '%s'", valueTypeName, enum.Name())
+	}
+
+	keyType := valueType
+	if kt := enum.KeyType(); kt != nil {
+		if keyType, ok = g.valueTypes[kt.String()]; !ok {
+			return nil, fmt.Errorf("unsupported key type '%s' for enum '%s'", kt.String(), enum.Name())
+		}
+	}
+
+	data := &TemplateData{
+		EnumName:       enum.Name(),
+		EnumDoc:        enum.Doc(),
+		EnumDeprecated: deprecation(enum.Annotations(), enum.Name()),
+		Table:          "_" + strings.ToUpper(strcase.ToSnake(enum.Name())),
+		KeyType:        keyType.name,
+		ValueType:      valueType.name,
+		Fields:         fields,
+		IsFlags:        enum.IsFlags(),
+		HasValues:      enum.KeyType() != nil,
+	}
+
+	switch {
+	case enum.IsFlags():
+		// Flag sets are keyed by their member names, e.g. "READ|WRITE".
+		if valueType.primitive.Kind != primitive.Int {
+			return nil, fmt.Errorf("flags enum '%s' requires an integer value type, got '%s'", enum.Name(), valueTypeName)
+		}
+		data.Base = "enum.Flag"
+		data.KeyType = "str"
+	case fields != nil || data.HasValues:
+		data.Base = "enum.Enum"
+	case valueType.name == "str":
+		data.Base = "enum.StrEnum"
+	case valueType.name == "int":
+		data.Base = "enum.IntEnum"
+	default:
+		data.Base = "enum.Enum"
+	}
+
+	keys := make([]string, 0, len(enum.Members()))
+	owners := make(map[string]string, len(enum.Members()))
+	for i, member := range enum.Members() {
+		if err := checkName(member.Name()); err != nil {
+			return nil, fmt.Errorf("member '%s': %w", member.Name(), err)
+		}
+
+		var keyIR, valueIR compiler.IRValue
+		var fieldValues []string
+
+		switch v := member.Value().(type) {
+		case compiler.IRKeyValue:
+			keyIR = referencedValue(v.Key())
+			valueIR = referencedValue(v.Value())
+		case compiler.IRTuple:
+			fieldValues, err = g.formatTuple(enum, v, member.Name())
+			if err != nil {
+				return nil, err
+			}
+		default:
+			keyIR = referencedValue(member.Value())
+			valueIR = keyIR
+		}
+
+		var key any = member.Name()
+		if !enum.IsFlags() {
+			key, err = keyType.primitive.Decode(keyIR, member.Name(), i)
+			if err != nil {
+				return nil, fmt.Errorf("error formatting key for member '%s': %w", member.Name(), err)
+			}
+		}
+		value, err := valueType.primitive.Decode(valueIR, member.Name(), i)
+		if err != nil {
+			return nil, fmt.Errorf("error formatting value for member '%s': %w", member.Name(), err)
+		}
+
+		keyLiteral := strconv.Quote(member.Name())
+		if !enum.IsFlags() {
+			if keyLiteral, err = keyType.literal(key); err != nil {
+				return nil, fmt.Errorf("invalid key for member '%s': %w", member.Name(), err)
+			}
+		}
+		valueLiteral, err := valueType.literal(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for member '%s': %w", member.Name(), err)
+		}
+
+		aliases := member.Aliases()
+		if len(aliases) > 0 && data.KeyType != "str" {
+			return nil, fmt.Errorf("member '%s' declares aliases, but the key type is '%s'", member.Name(), data.KeyType)
+		}
+		lookups := []string{keyLiteral}
+		for _, alias := range aliases {
+			lookups = append(lookups, strconv.Quote(alias))
+		}
+		data.HasAliases = data.HasAliases || len(aliases) > 0
+
+		m := TemplateMember{
+			Name:       member.Name(),
+			Doc:        member.Doc(),
+			Deprecated: deprecation(member.Annotations(), member.Name()),
+			Lookups:    lookups,
+			Fields:     fieldValues,
+		}
+		switch {
+		case fields != nil:
+			m.Value = tuple(fieldValues)
+		case data.HasValues:
+			m.Value = tuple([]string{keyLiteral, valueLiteral})
+			m.RawValue = valueLiteral
+		case enum.IsFlags():
+			m.Value = valueLiteral
+		default:
+			m.Value = keyLiteral
+		}
+
+		// Python turns members with equal values into aliases of each other.
+		if owner, ok := owners[m.Value]; ok {
+			return nil, fmt.Errorf("members '%s' and '%s' have the same value %s, which Python would treat as aliases", owner, m.Name, m.Value)
+		}
+		owners[m.Value] = m.Name
+
+		data.Members = append(data.Members, m)
+		keys = append(keys, keyString(key))
+	}
+	data.ValidKeys = "one of: " + strings.Join(keys, ", ")
+
+	for _, member := range enum.MemberAliases() {
+		ref, ok := member.Value().(compiler.IRReference)
+		if !ok {
+			return nil, fmt.Errorf("member '%s' is not assigned another member", member.Name())
+		}
+		if err := checkName(member.Name()); err != nil {
+			return nil, fmt.Errorf("member '%s': %w", member.Name(), err)
+		}
+		data.MemberAliases = append(data.MemberAliases, TemplateMemberAlias{
+			Name:       member.Name(),
+			Target:     ref.Member(),
+			Doc:        member.Doc(),
+			Deprecated: deprecation(member.Annotations(), member.Name()),
+		})
+	}
+
+	return data, nil
+}
+
+// checkName reports member names Python cannot use for enum members.
+func checkName(name string) error {
+	switch {
+	case keywords[name]:
+		return fmt.Errorf("'%s' is a Python keyword", name)
+	case reservedAttributes[name]:
+		return fmt.Errorf("'%s' would replace an attribute of the enum class", name)
+	case strings.HasPrefix(name, "_"):
+		return fmt.Errorf("enum members cannot start with an underscore in Python")
+	}
+	return nil
+}
+
+// prepareFields returns the fields of a tuple enum, or nil for other enums.
+func (g *Generator) prepareFields(enum compiler.IREnumDefinition) ([]TemplateField, error) {
+	if len(enum.Fields()) == 0 {
+		return nil, nil
+	}
+
+	fields := make([]TemplateField, 0, len(enum.Fields()))
+	owners := make(map[string]string)
+	for _, field := range enum.Fields() {
+		if field.Type() == nil {
+			return nil, fmt.Errorf("field '%s' of enum '%s' has no type", field.Name(), enum.Name())
+		}
+
+		typeName := field.Type().Name()
+		if field.Type().Kind() != compiler.TypeEnum {
+			t, ok := g.valueTypes[field.Type().String()]
+			if !ok {
+				return nil, fmt.Errorf("unsupported type '%s' of field '%s' in enum '%s'", field.Type().String(), field.Name(), enum.Name())
+			}
+			typeName = t.name
+		}
+
+		attr := strcase.ToSnake(field.Name())
+		if keywords[attr] {
+			attr += "_"
+		}
+		if reservedAttributes[attr] || reservedAttributes["from_"+attr] {
+			return nil, fmt.Errorf("field '%s' of enum '%s' would replace the attribute '%s' of the enum class", field.Name(), enum.Name(), attr)
+		}
+		if prev, ok := owners[attr]; ok {
+			return nil, fmt.Errorf("fields '%s' and '%s' of enum '%s' both map to the property '%s'", prev, field.Name(), enum.Name(), attr)
+		}
+		owners[attr] = field.Name()
+
+		fields = append(fields, TemplateField{
+			Name:   field.Name(),
+			Attr:   attr,
+			Table:  strings.ToUpper(strings.TrimSuffix(attr, "_")),
+			Type:   typeName,
+			Unique: field.IsUnique(),
+		})
+	}
+	return fields, nil
+}
+
+// formatTuple renders the values of a tuple member in field order. Fields of
+// an enum type hold references to its members.
+func (g *Generator) formatTuple(enum compiler.IREnumDefinition, tuple compiler.IRTuple, memberName string) ([]string, error) {
+	fields := enum.Fields()
+	if len(tuple.Elements()) != len(fields) {
+		return nil, fmt.Errorf("member '%s' has %d values, but enum '%s' declares %d fields", memberName, len(tuple.Elements()), enum.Name(), len(fields))
+	}
+
+	values := make([]string, len(fields))
+	for i, elt := range tuple.Elements() {
+		fieldType := fields[i].Type()
+		if fieldType.Kind() == compiler.TypeEnum {
+			ref, ok := elt.(compiler.IRReference)
+			if !ok || ref.Enum() != fieldType.Name() {
+				return nil, fmt.Errorf("field '%s' of member '%s' must reference a member of '%s', got %v", fields[i].Name(), memberName, fieldType.Name(), elt)
+			}
+			values[i] = ref.Enum() + "." + ref.Member()
+			continue
+		}
+
+		t := g.valueTypes[fieldType.String()]
+		value, err := t.primitive.Decode(referencedValue(elt), memberName, i)
+		if err == nil {
+			values[i], err = t.literal(value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error formatting field '%s' of member '%s': %w", fields[i].Name(), memberName, err)
+		}
+	}
+	return values, nil
+}
+
+// tuple renders elements as a Python tuple; a single element needs a trailing comma.
+func tuple(elements []string) string {
+	if len(elements) == 1 {
+		return "(" + elements[0] + ",)"
+	}
+	return "(" + strings.Join(elements, ", ") + ")"
+}
+
+// referencedValue returns the value a member reference stands for, or v
+// itself if it is not a reference.
+func referencedValue(v compiler.IRValue) compiler.IRValue {
+	if ref, ok := v.(compiler.IRReference); ok {
+		return ref.Target()
+	}
+	return v
+}
+
+// deprecation returns the deprecation notice of a @deprecated declaration named name, or "".
+func deprecation(annotations []compiler.IRAnnotation, name string) string {
+	for _, a := range annotations {
+		if a.Name() != "deprecated" {
+			continue
+		}
+		if len(a.Args()) > 0 && a.Args()[0] != "" {
+			return a.Args()[0]
+		}
+		return name + " should no longer be used."
+	}
+	return ""
+}
+
+// keyString renders a key for error messages.
+func keyString(key any) string {
+	if r, ok := key.(rune); ok {
+		return string(r)
+	}
+	return fmt.Sprint(key)
+}
+
+// dependencies returns the names of the other enums the fields of enum refer to.
+func dependencies(enum compiler.IREnumDefinition) []string {
+	var deps []string
+	for _, field := range enum.Fields() {
+		t := field.Type()
+		if t == nil || t.Kind() != compiler.TypeEnum || t.Name() == enum.Name() {
+			continue
+		}
+		if !slices.Contains(deps, t.Name()) {
+			deps = append(deps, t.Name())
+		}
+	}
+	return deps
+}
+
+// orderEnums returns the enums in declaration order, except that every enum
+// comes after the enums its fields refer to.
+func orderEnums(enums []compiler.IREnumDefinition) []compiler.IREnumDefinition {
+	byName := make(map[string]compiler.IREnumDefinition, len(enums))
+	for _, enum := range enums {
+		byName[enum.Name()] = enum
+	}
+
+	ordered := make([]compiler.IREnumDefinition, 0, len(enums))
+	visited := make(map[string]bool, len(enums))
+	var visit func(enum compiler.IREnumDefinition)
+	visit = func(enum compiler.IREnumDefinition) {
+		if visited[enum.Name()] {
+			return
+		}
+		visited[enum.Name()] = true
+		for _, dep := range dependencies(enum) {
+			if d, ok := byName[dep]; ok {
+				visit(d)
+			}
+		}
+		ordered = append(ordered, enum)
+	}
+	for _, enum := range enums {
+		visit(enum)
+	}
+	return ordered
+}
@@ -0,0 +1,143 @@
+package python_test
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kkumar-gcc/enumgen/src/codegen/internal/testutil"
+)
+
+const sampleSource = testutil.SampleSource + `
+enum HTTPStatus [code int unique, text string, status Status]:
+    OK = (200, "OK", Status.SUCCESS),
+    UNAVAILABLE = (503, "Service Unavailable", Status.FAILURE);
+
+enum Ratio [float64]:
+    ONE = 1,
+    HALF = 0.5;
+`
+
+func TestGenerate(t *testing.T) {
+	files := testutil.Generate(t, "python", sampleSource, nil)
+	code, ok := files["enums.py"]
+	if !ok || len(files) != 1 {
+		t.Fatalf("expected a single enums.py, got %v", files)
+	}
+
+	testutil.AssertContains(t, code,
+		"\"\"\"Enums shared with the services.\"\"\"\n\nfrom __future__ import annotations\n\nimport enum\n",
+		"class Status(enum.IntEnum):\n    \"\"\"Status of an operation.\"\"\"\n\n    SUCCESS = 1\n",
+		"    FAILURE = ERROR\n",
+		"class Color(enum.StrEnum):",
+		"    # Deprecated: use RED\n    GREEN = \"green\"\n",
+		"    def from_key(cls, key: str) -> Color:",
+		"    \"crimson\": Color.RED,\n",
+		"class Day(enum.Enum):\n    MONDAY = (\"Monday\", 1)\n",
+		"    def raw_value(self) -> int:",
+		"_DAY_BY_VALUE: dict[int, Day] = {\n    1: Day.MONDAY,\n",
+		"    OK = (200, \"OK\", Status.SUCCESS)\n",
+		"    def __init__(self, code: int, text: str, status: Status) -> None:",
+		"    def from_code(cls, value: int) -> HTTPStatus:",
+		"_HTTP_STATUS_BY_CODE: dict[int, HTTPStatus] = {",
+		"class Perm(enum.Flag):\n    READ = 1\n    WRITE = 2\n    EXEC = 4\n",
+		"class Ratio(enum.Enum):\n    ONE = 1.0\n    HALF = 0.5\n",
+	)
+	if strings.Contains(code, "from_text") {
+		t.Errorf("fields not marked unique should not have a lookup")
+	}
+	if strings.Index(code, "class Status(") > strings.Index(code, "class HTTPStatus(") {
+		t.Errorf("Status should be defined before HTTPStatus")
+	}
+}
+
+func TestGenerateRuns(t *testing.T) {
+	python := testutil.LookPath(t, "python3")
+	if out, err := exec.Command(python, "-c", "import enum; enum.StrEnum").CombinedOutput(); err != nil {
+		t.Skipf("python3 lacks enum.StrEnum: %s", out)
+	}
+
+	dir := t.TempDir()
+	files := testutil.Generate(t, "python", sampleSource+testutil.AliasSource, map[string]string{"output_mode": "per_enum"})
+	files["__init__.py"] = ""
+	testutil.WriteFiles(t, filepath.Join(dir, "enums"), files)
+
+	script := `
+from enums.status import Status
+from enums.color import Color
+from enums.day import Day
+from enums.httpstatus import HTTPStatus
+from enums.perm import Perm
+from enums.mode import Mode
+from enums.point import Point
+
+assert Status.FAILURE is Status.ERROR
+assert Status.from_key(3) is Status.ERROR and Status.from_value(1) is Status.SUCCESS
+assert Color.from_key("crimson") is Color.RED
+assert Day.from_key("Monday") is Day.MONDAY and Day.from_value(2) is Day.TUESDAY
+assert Day.MONDAY.key == "Monday" and Day.MONDAY.raw_value == 1
+assert HTTPStatus.from_code(503) is HTTPStatus.UNAVAILABLE
+assert HTTPStatus.UNAVAILABLE.status is Status.ERROR
+assert HTTPStatus.from_key("OK").text == "OK"
+assert Perm.from_key("READ | W") == Perm.READ | Perm.WRITE
+assert Perm.from_key("0") == Perm(0)
+assert Mode.RUN is Mode.EXEC and Mode.from_key("r|w") == Mode.READ | Mode.WRITE
+assert Point.ZERO is Point.ORIGIN
+assert Point.from_x(1) is Point.UNIT and Point.from_label("origin") is Point.ORIGIN
+try:
+    Color.from_key("purple")
+    raise AssertionError("expected ValueError")
+except ValueError as e:
+    assert "expected one of: red, green, blue" in str(e)
+`
+	testutil.Run(t, dir, python, "-c", script)
+}
+
+func TestGenerateImports(t *testing.T) {
+	files := testutil.Generate(t, "python", sampleSource, map[string]string{"output_mode": "per_enum"})
+	if len(files) != 6 {
+		t.Fatalf("expected 6 files, got %d", len(files))
+	}
+	testutil.AssertContains(t, files["httpstatus.py"], "import enum\n\nfrom .status import Status\n")
+	if strings.Contains(files["status.py"], "from .") {
+		t.Errorf("status.py should not import other enums")
+	}
+	for name, code := range files {
+		if strings.Contains(code, "Enums shared with the services.") {
+			t.Errorf("%s should not repeat the package doc", name)
+		}
+	}
+}
+
+func TestGenerateInvalid(t *testing.T) {
+	tests := map[string]string{
+		"keyword member": `
+enum Answer [bool]:
+    True = true,
+    False = false;
+`,
+		"reserved member": `
+enum Field [string]:
+    name = "name",
+    value = "value";
+`,
+		"reserved field": `
+enum Column [name string, width int]:
+    ID = ("id", 8);
+`,
+		"equal tuples": `
+enum Point [x int, y int]:
+    ORIGIN = (0, 0),
+    ZERO = (0, 0);
+`,
+	}
+	for name, source := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, err := testutil.Compile(t, "python", source, nil)
+			if err == nil && len(ctx.Errors) == 0 && !ctx.Validations.HasErrors() {
+				t.Fatalf("expected the enum to be rejected")
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package python
+
+import (
+	"embed"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+const (
+	// enumTemplate renders the class of an enum and its lookup tables.
+	enumTemplate = "templates/enum.py.tmpl"
+
+	// fileTemplate wraps the rendered enums with the header, docstring and
+	// imports, so these are emitted once per generated module.
+	fileTemplate = "templates/file.py.tmpl"
+)
+
+var templateFuncs = template.FuncMap{
+	"comment":   comment,
+	"docstring": docstring,
+	"join":      strings.Join,
+	"quote":     strconv.Quote,
+}
+
+// docLines returns the lines of a doc comment, followed by a deprecation
+// paragraph if deprecated is set.
+func docLines(text, deprecated string) []string {
+	var lines []string
+	if text != "" {
+		lines = strings.Split(text, "\n")
+	}
+	if deprecated != "" {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "Deprecated: "+deprecated)
+	}
+	return lines
+}
+
+// comment renders text as Python comments. Every line after the first is
+// prefixed with indent so the block lines up with the first line.
+func comment(indent, text, deprecated string) string {
+	lines := docLines(text, deprecated)
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = "#"
+		} else {
+			lines[i] = "# " + line
+		}
+		if i > 0 {
+			lines[i] = indent + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// docstring renders text as a Python docstring, indented like comment.
+func docstring(indent, text, deprecated string) string {
+	lines := docLines(text, deprecated)
+	for i, line := range lines {
+		line = strings.ReplaceAll(line, `\`, `\\`)
+		lines[i] = strings.ReplaceAll(line, `"""`, `\"\"\"`)
+	}
+	if n := len(lines); n > 0 && strings.HasSuffix(lines[n-1], `"`) {
+		lines[n-1] = strings.TrimSuffix(lines[n-1], `"`) + `\"`
+	}
+
+	if len(lines) == 1 {
+		return `"""` + lines[0] + `"""`
+	}
+
+	var sb strings.Builder
+	for i, line := range lines {
+		if i == 0 {
+			sb.WriteString(`"""` + line + "\n")
+			continue
+		}
+		if line != "" {
+			sb.WriteString(indent + line)
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(indent + `"""`)
+	return sb.String()
+}
+
+type TemplateMember struct {
+	Name       string
+	Doc        string
+	Deprecated string   // deprecation notice from @deprecated, if any
+	Value      string   // expression the member is assigned
+	RawValue   string   // literal of the value paired with the key, in key-value enums
+	Lookups    []string // literals of the keys accepted by from_key, including aliases
+	Fields     []string // expressions of the fields of a tuple member, in field order
+}
+
+// TemplateMemberAlias is a member assigned another member of its enum, which
+// becomes an alias of that member.
+type TemplateMemberAlias struct {
+	Name       string
+	Target     string
+	Doc        string
+	Deprecated string
+}
+
+// TemplateField is a named field of a tuple enum.
+type TemplateField struct {
+	Name   string // field name in EDL
+	Attr   string // property and parameter name in Python
+	Table  string // upper case suffix of the lookup table of a unique field
+	Type   string // Python type of the field
+	Unique bool   // whether members can be looked up by the field's value
+}
+
+// Import names enums declared in another generated module.
+type Import struct {
+	Module string
+	Names  []string
+}
+
+// FileData holds everything that appears once per generated module. Enums
+// are the already rendered bodies of the enum template.
+type FileData struct {
+	EDLVersion  string
+	ToolVersion string
+	Doc         string
+	Imports     []Import
+	Enums       []string
+}
+
+type TemplateData struct {
+	EnumName       string
+	EnumDoc        string
+	EnumDeprecated string
+	Base           string // base class, such as enum.StrEnum
+	Table          string // prefix of the module level lookup tables, e.g. _HTTP_STATUS
+	KeyType        string
+	ValueType      string
+	Members        []TemplateMember
+	MemberAliases  []TemplateMemberAlias
+	Fields         []TemplateField
+	IsFlags        bool
+	HasValues      bool // whether members pair a key with a value
+	HasAliases     bool
+	ValidKeys      string
+}
+
+func loadTemplate(name, path string, fs embed.FS) (*template.Template, error) {
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", path, err)
+	}
+
+	return tmpl, nil
+}
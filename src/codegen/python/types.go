@@ -0,0 +1,77 @@
+package python
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kkumar-gcc/enumgen/src/codegen/primitive"
+)
+
+// valueType pairs the Python type of an EDL type with the primitive type
+// that decodes its literals.
+type valueType struct {
+	name      string
+	primitive primitive.Type
+}
+
+func defaultValueTypes() map[string]valueType {
+	integer := func(edl string) valueType {
+		return valueType{name: "int", primitive: primitive.MustLookup(edl)}
+	}
+	float := func(edl string) valueType {
+		return valueType{name: "float", primitive: primitive.MustLookup(edl)}
+	}
+	return map[string]valueType{
+		"char":    {name: "str", primitive: primitive.MustLookup("char")},
+		"string":  {name: "str", primitive: primitive.MustLookup("string")},
+		"int":     integer("int"),
+		"int8":    integer("int8"),
+		"int16":   integer("int16"),
+		"int32":   integer("int32"),
+		"rune":    integer("rune"),
+		"int64":   integer("int64"),
+		"uint":    integer("uint"),
+		"uint8":   integer("uint8"),
+		"byte":    integer("byte"),
+		"uint16":  integer("uint16"),
+		"uint32":  integer("uint32"),
+		"uint64":  integer("uint64"),
+		"float":   float("float"),
+		"float32": float("float32"),
+		"float64": float("float64"),
+		"bool":    {name: "bool", primitive: primitive.MustLookup("bool")},
+	}
+}
+
+// literal renders a value decoded by the primitive type of t as a Python
+// literal.
+func (t valueType) literal(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return strconv.Quote(v), nil
+	case rune:
+		return strconv.Quote(string(v)), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		s := strconv.FormatFloat(v, 'g', -1, t.primitive.BitSize)
+		if strings.ContainsAny(s, "IN") {
+			return "", fmt.Errorf("float %v cannot be written as a Python literal", v)
+		}
+		if !strings.ContainsAny(s, ".e") {
+			// Keep integral floats floats, e.g. 1.0 rather than 1.
+			s += ".0"
+		}
+		return s, nil
+	case bool:
+		if v {
+			return "True", nil
+		}
+		return "False", nil
+	default:
+		return "", fmt.Errorf("internal error: unsupported value %#v", v)
+	}
+}
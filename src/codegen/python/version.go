@@ -0,0 +1,5 @@
+package python
+
+const (
+	Version = "v0.0.1"
+)
@@ -0,0 +1,594 @@
+package rust
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/kkumar-gcc/enumgen/pkg/strcase"
+	"github.com/kkumar-gcc/enumgen/src/codegen/contracts"
+	"github.com/kkumar-gcc/enumgen/src/codegen/output"
+	"github.com/kkumar-gcc/enumgen/src/codegen/primitive"
+	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
+	"github.com/kkumar-gcc/enumgen/src/version"
+)
+
+var _ contracts.Generator = (*Generator)(nil)
+
+// keywords are the strict and reserved keywords of Rust, which cannot name
+// types, variants or methods.
+var keywords = map[string]bool{
+	"as": true, "async": true, "await": true, "break": true, "const": true,
+	"continue": true, "crate": true, "dyn": true, "else": true, "enum": true,
+	"extern": true, "false": true, "fn": true, "for": true, "if": true,
+	"impl": true, "in": true, "let": true, "loop": true, "match": true,
+	"mod": true, "move": true, "mut": true, "pub": true, "ref": true,
+	"return": true, "self": true, "Self": true, "static": true, "struct": true,
+	"super": true, "trait": true, "true": true, "type": true, "unsafe": true,
+	"use": true, "where": true, "while": true, "abstract": true, "become": true,
+	"box": true, "do": true, "final": true, "macro": true, "override": true,
+	"priv": true, "try": true, "typeof": true, "unsized": true, "virtual": true,
+	"yield": true,
+}
+
+// rawKeywords are the keywords that cannot be used as raw identifiers.
+var rawKeywords = map[string]bool{"crate": true, "self": true, "Self": true, "super": true}
+
+// reservedMethods are methods of generated enums that field accessors must
+// not replace.
+var reservedMethods = map[string]bool{
+	"as_key": true, "as_value": true, "from_key": true, "from_value": true,
+}
+
+type Generator struct {
+	enum       *template.Template
+	flags      *template.Template
+	file       *template.Template
+	valueTypes map[string]valueType
+}
+
+func New() (*Generator, error) {
+	templates := make(map[string]*template.Template, 3)
+	for name, path := range map[string]string{"enum": enumTemplate, "flags": flagsTemplate, "file": fileTemplate} {
+		tmpl, err := loadTemplate(name, path, templatesFS)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load templates: %w", err)
+		}
+		if tmpl, err = tmpl.ParseFS(templatesFS, partialTemplates); err != nil {
+			return nil, fmt.Errorf("failed to parse partial templates: %w", err)
+		}
+		templates[name] = tmpl
+	}
+
+	return &Generator{
+		enum:       templates["enum"],
+		flags:      templates["flags"],
+		file:       templates["file"],
+		valueTypes: defaultValueTypes(),
+	}, nil
+}
+
+func (g *Generator) Name() string {
+	return "Rust"
+}
+
+func (g *Generator) Language() string {
+	return "rust"
+}
+
+func (g *Generator) DefaultOptions() map[string]string {
+	return defaultOptions
+}
+
+func (g *Generator) OptionHelp() string {
+	sb := strings.Builder{}
+	sb.WriteString("Available options for " + g.Name() + " code generation:\n")
+	for key, value := range g.DefaultOptions() {
+		help := optionHelp[key]
+		if help == "" {
+			sb.WriteString(fmt.Sprintf("  - %s (default: %s)\n", key, value))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("  - %s: %s (default: %s)\n", key, help, value))
+	}
+	return sb.String()
+}
+
+func (g *Generator) Generate(module compiler.IRModule, options map[string]string) ([]*compiler.OutputFile, error) {
+	opts := maps.Clone(g.DefaultOptions())
+	maps.Copy(opts, options)
+
+	outputMode, pattern, err := output.Resolve(opts[OptionOutputMode], opts[OptionFileName], ".rs")
+	if err != nil {
+		return nil, err
+	}
+	serde, err := strconv.ParseBool(opts[OptionGenerateSerde])
+	if err != nil {
+		return nil, fmt.Errorf("invalid value '%s' for option '%s': %w", opts[OptionGenerateSerde], OptionGenerateSerde, err)
+	}
+
+	byName := make(map[string]compiler.IREnumDefinition, len(module.Enums()))
+	for _, enum := range module.Enums() {
+		byName[enum.Name()] = enum
+	}
+
+	enums := make([]string, 0, len(module.Enums()))
+	for _, enum := range module.Enums() {
+		code, err := g.generateEnum(enum, byName, serde)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate code for enum '%s': %w", enum.Name(), err)
+		}
+		enums = append(enums, code)
+	}
+
+	if outputMode == output.Single {
+		if len(enums) == 0 {
+			return nil, nil
+		}
+
+		fileName := output.FileName(pattern, module.Name(), "")
+		code, err := g.generateFile(module.Doc(), nil, enums)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate file '%s': %w", fileName, err)
+		}
+		return []*compiler.OutputFile{{Path: fileName, Body: code}}, nil
+	}
+
+	fileNames, err := output.FileNames(pattern, module)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*compiler.OutputFile, 0, len(enums))
+	for i, enum := range module.Enums() {
+		fileName := fileNames[enum.Name()]
+
+		// Every enum is a module of its own, so enums referred to by fields
+		// are imported from the sibling modules.
+		var imports []string
+		for _, dep := range dependencies(enum) {
+			path := filepath.Base(fileNames[dep])
+			imports = append(imports, "super::"+strings.TrimSuffix(path, filepath.Ext(path))+"::"+dep)
+		}
+
+		// The package doc describes the module as a whole rather than any one
+		// enum, so it is only written to the single file.
+		code, err := g.generateFile("", imports, enums[i:i+1])
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate code for enum '%s': %w", enum.Name(), err)
+		}
+
+		files = append(files, &compiler.OutputFile{
+			Path: fileName,
+			Body: code,
+		})
+	}
+
+	return files, nil
+}
+
+// generateFile wraps rendered enums with the module header and imports.
+func (g *Generator) generateFile(doc string, imports []string, enums []string) ([]byte, error) {
+	data := FileData{
+		EDLVersion:  version.Version,
+		ToolVersion: Version,
+		Doc:         doc,
+		Imports:     imports,
+		Enums:       enums,
+	}
+
+	var buf bytes.Buffer
+	if err := g.file.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return tidySource(buf.Bytes()), nil
+}
+
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+// tidySource collapses runs of blank lines left by the templates and ends the
+// source with a single newline.
+func tidySource(src []byte) []byte {
+	src = blankLines.ReplaceAll(src, []byte("\n\n"))
+	return append(bytes.TrimRight(src, "\n"), '\n')
+}
+
+// generateEnum renders a single enum and its trait impls.
+func (g *Generator) generateEnum(enum compiler.IREnumDefinition, enums map[string]compiler.IREnumDefinition, serde bool) (string, error) {
+	data, err := g.prepareTemplateData(enum, enums)
+	if err != nil {
+		return "", fmt.Errorf("failed to prepare data: %w", err)
+	}
+	data.Serde = serde
+
+	tmpl := g.enum
+	if data.IsFlags {
+		tmpl = g.flags
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+func (g *Generator) prepareTemplateData(enum compiler.IREnumDefinition, enums map[string]compiler.IREnumDefinition) (*TemplateData, error) {
+	if keywords[enum.Name()] {
+		return nil, fmt.Errorf("enum '%s' cannot be named after a Rust keyword", enum.Name())
+	}
+	if len(enum.Members()) == 0 {
+		return nil, fmt.Errorf("enum '%s' has no members", enum.Name())
+	}
+
+	fields, err := g.prepareFields(enum)
+	if err != nil {
+		return nil, err
+	}
+
+	valueTypeName := "string" // tuple members are keyed by their names
+	if fields == nil {
+		valueType := enum.ValueType()
+		if valueType == nil {
+			return nil, fmt.Errorf("enum '%s' has no value type defined", enum.Name())
+		}
+		valueTypeName = valueType.String()
+	}
+	valueType, ok := g.valueTypes[valueTypeName]
+	if !ok {
+		return nil, fmt.Errorf("type '%s' of enum '%s' has no Rust equivalent", valueTypeName, enum.Name())
+	}
+
+	keyType := valueType
+	if kt := enum.KeyType(); kt != nil {
+		if keyType, ok = g.valueTypes[kt.String()]; !ok {
+			return nil, fmt.Errorf("key type '%s' of enum '%s' has no Rust equivalent", kt.String(), enum.Name())
+		}
+	}
+
+	data := &TemplateData{
+		EnumName:       enum.Name(),
+		EnumDoc:        enum.Doc(),
+		EnumDeprecated: deprecation(enum.Annotations(), enum.Name()),
+		ErrorName:      "Invalid" + enum.Name(),
+		KeyType:        keyType.name,
+		KeyParam:       param(keyType),
+		ValueType:      valueType.name,
+		ValueParam:     param(valueType),
+		Fields:         fields,
+		IsFlags:        enum.IsFlags(),
+		ParseKey:       "s.parse::<" + keyType.name + ">().ok().and_then(" + enum.Name() + "::from_key)",
+		OwnedKeyType:   keyType.name,
+	}
+	if keyType.isString() {
+		data.ParseKey = enum.Name() + "::from_key(s)"
+		data.OwnedKeyType = "String"
+	}
+
+	ident := variantIdent
+	if enum.IsFlags() {
+		// Flag sets are keyed by their member names, e.g. "READ|WRITE".
+		if valueType.primitive.Kind != primitive.Int {
+			return nil, fmt.Errorf("flags enum '%s' requires an integer value type, got '%s'", enum.Name(), valueTypeName)
+		}
+		ident = constIdent
+		keyType = g.valueTypes["string"]
+		data.KeyType = keyType.name
+	}
+
+	var mask uint64
+	var keyLiterals, valueLiterals []string
+	keys := make([]string, 0, len(enum.Members()))
+	owners := map[string]string{"ALL": "", "MASK": ""}
+	values := make(map[string]bool, len(enum.Members()))
+	for i, member := range enum.Members() {
+		m := TemplateMember{
+			Name:       member.Name(),
+			Ident:      ident(member.Name()),
+			Doc:        member.Doc(),
+			Deprecated: deprecation(member.Annotations(), member.Name()),
+		}
+		if err := claim(owners, m.Ident, m.Name); err != nil {
+			return nil, err
+		}
+
+		var keyIR, valueIR compiler.IRValue
+		switch v := member.Value().(type) {
+		case compiler.IRKeyValue:
+			keyIR = referencedValue(v.Key())
+			valueIR = referencedValue(v.Value())
+		case compiler.IRTuple:
+			if m.Fields, err = g.formatTuple(enum, v, member.Name(), enums); err != nil {
+				return nil, err
+			}
+		default:
+			keyIR = referencedValue(member.Value())
+			valueIR = keyIR
+		}
+
+		var key any = member.Name()
+		if !enum.IsFlags() {
+			key, err = keyType.primitive.Decode(keyIR, member.Name(), i)
+			if err != nil {
+				return nil, fmt.Errorf("error formatting key for member '%s': %w", member.Name(), err)
+			}
+		}
+		value, err := valueType.primitive.Decode(valueIR, member.Name(), i)
+		if err != nil {
+			return nil, fmt.Errorf("error formatting value for member '%s': %w", member.Name(), err)
+		}
+
+		if m.Key, err = keyType.literal(key); err != nil {
+			return nil, fmt.Errorf("invalid key for member '%s': %w", member.Name(), err)
+		}
+		if m.Value, err = valueType.literal(value); err != nil {
+			return nil, fmt.Errorf("invalid value for member '%s': %w", member.Name(), err)
+		}
+
+		if enum.IsFlags() {
+			bits, ok := value.(uint64)
+			if v, signed := value.(int64); signed {
+				bits, ok = uint64(v), v >= 0
+			}
+			if !ok {
+				return nil, fmt.Errorf("flag '%s' has the negative value %s", member.Name(), m.Value)
+			}
+			mask |= bits
+		}
+
+		aliases := member.Aliases()
+		if len(aliases) > 0 && !keyType.isString() {
+			return nil, fmt.Errorf("member '%s' declares aliases, but the key type is '%s'", member.Name(), keyType.name)
+		}
+		lookups := []string{m.Key}
+		for _, alias := range aliases {
+			lit, _ := keyType.literal(alias)
+			lookups = append(lookups, lit)
+		}
+		data.HasAliases = data.HasAliases || len(aliases) > 0
+		m.KeyPattern = strings.Join(lookups, " | ")
+
+		// Later members with the value of an earlier one are unreachable by value.
+		if !values[m.Value] {
+			values[m.Value] = true
+			m.ValuePattern = m.Value
+		}
+
+		keyLiterals = append(keyLiterals, m.Key)
+		valueLiterals = append(valueLiterals, m.Value)
+		data.HasDeprecated = data.HasDeprecated || m.Deprecated != ""
+		data.Members = append(data.Members, m)
+		keys = append(keys, keyString(key))
+	}
+
+	data.KeysExhaustive = exhaustive(keyType, keyLiterals)
+	data.ValuesExhaustive = exhaustive(valueType, valueLiterals)
+	for i := range data.Fields {
+		literals := make([]string, len(data.Members))
+		for j, m := range data.Members {
+			literals[j] = m.Fields[i]
+		}
+		data.Fields[i].Exhaustive = exhaustive(g.valueTypes[enum.Fields()[i].Type().String()], literals)
+	}
+
+	validKeys := "one of: " + strings.Join(keys, ", ")
+	if enum.IsFlags() {
+		validKeys = "a '|' separated list of: " + strings.Join(keys, ", ")
+		data.FlagMask = strconv.FormatUint(mask, 10)
+	}
+	// The keys are written into the format string of the error message.
+	data.ValidKeys = escape(strings.NewReplacer("{", "{{", "}", "}}").Replace(validKeys), '"')
+
+	for _, member := range enum.MemberAliases() {
+		ref, ok := member.Value().(compiler.IRReference)
+		if !ok {
+			return nil, fmt.Errorf("member '%s' is not assigned another member", member.Name())
+		}
+		alias := TemplateMemberAlias{
+			Name:       member.Name(),
+			Ident:      constIdent(member.Name()),
+			Target:     memberIdent(enum, ref.Member()),
+			Doc:        member.Doc(),
+			Deprecated: deprecation(member.Annotations(), member.Name()),
+		}
+		if err := claim(owners, alias.Ident, alias.Name); err != nil {
+			return nil, err
+		}
+		data.HasDeprecated = data.HasDeprecated || alias.Deprecated != ""
+		data.MemberAliases = append(data.MemberAliases, alias)
+	}
+	data.HasDeprecated = data.HasDeprecated || data.EnumDeprecated != ""
+
+	return data, nil
+}
+
+// claim records that the member name owns ident, and reports members of the
+// same enum, or the items generated for it, whose Rust names collide.
+func claim(owners map[string]string, ident, name string) error {
+	if keywords[ident] {
+		return fmt.Errorf("member '%s' becomes the Rust keyword '%s'", name, ident)
+	}
+	if owner, ok := owners[ident]; ok {
+		if owner == "" {
+			return fmt.Errorf("member '%s' would replace the generated constant '%s'", name, ident)
+		}
+		return fmt.Errorf("members '%s' and '%s' both map to '%s' in Rust", owner, name, ident)
+	}
+	owners[ident] = name
+	return nil
+}
+
+// variantIdent returns the Rust variant of a member, e.g. Success for SUCCESS.
+func variantIdent(name string) string {
+	return strcase.ToPascal(name)
+}
+
+// constIdent returns the associated constant of a flag or alias, e.g. READ_WRITE.
+func constIdent(name string) string {
+	return strings.ToUpper(strcase.ToSnake(name))
+}
+
+// memberIdent returns the Rust name of the member of enum with the given
+// name, which is an associated constant for flags and aliases.
+func memberIdent(enum compiler.IREnumDefinition, name string) string {
+	if enum.IsFlags() {
+		return constIdent(name)
+	}
+	for _, alias := range enum.MemberAliases() {
+		if alias.Name() == name {
+			return constIdent(name)
+		}
+	}
+	return variantIdent(name)
+}
+
+// param returns the parameter type of lookups by values of t, which borrow
+// strings instead of requiring 'static ones.
+func param(t valueType) string {
+	if t.isString() {
+		return "&str"
+	}
+	return t.name
+}
+
+// exhaustive reports whether literals cover every value of t, which is only
+// possible for booleans.
+func exhaustive(t valueType, literals []string) bool {
+	return t.name == "bool" && slices.Contains(literals, "true") && slices.Contains(literals, "false")
+}
+
+// prepareFields returns the fields of a tuple enum, or nil for other enums.
+func (g *Generator) prepareFields(enum compiler.IREnumDefinition) ([]TemplateField, error) {
+	if len(enum.Fields()) == 0 {
+		return nil, nil
+	}
+
+	fields := make([]TemplateField, 0, len(enum.Fields()))
+	owners := make(map[string]string)
+	for _, field := range enum.Fields() {
+		if field.Type() == nil {
+			return nil, fmt.Errorf("field '%s' of enum '%s' has no type", field.Name(), enum.Name())
+		}
+
+		typeName := field.Type().Name()
+		if field.Type().Kind() != compiler.TypeEnum {
+			t, ok := g.valueTypes[field.Type().String()]
+			if !ok {
+				return nil, fmt.Errorf("type '%s' of field '%s' in enum '%s' has no Rust equivalent", field.Type().String(), field.Name(), enum.Name())
+			}
+			typeName = t.name
+		}
+
+		method := strcase.ToSnake(field.Name())
+		if rawKeywords[method] {
+			return nil, fmt.Errorf("field '%s' of enum '%s' cannot be named after the Rust keyword '%s'", field.Name(), enum.Name(), method)
+		}
+		if reservedMethods[method] || (field.IsUnique() && reservedMethods["from_"+method]) {
+			return nil, fmt.Errorf("field '%s' of enum '%s' would replace a generated method", field.Name(), enum.Name())
+		}
+		if prev, ok := owners[method]; ok {
+			return nil, fmt.Errorf("fields '%s' and '%s' of enum '%s' both map to the method '%s'", prev, field.Name(), enum.Name(), method)
+		}
+		owners[method] = field.Name()
+		if keywords[method] {
+			method = "r#" + method
+		}
+
+		fields = append(fields, TemplateField{
+			Name:   field.Name(),
+			Method: method,
+			Type:   typeName,
+			Unique: field.IsUnique(),
+		})
+	}
+	return fields, nil
+}
+
+// formatTuple renders the values of a tuple member in field order. Fields of
+// an enum type hold members of that enum.
+func (g *Generator) formatTuple(enum compiler.IREnumDefinition, tuple compiler.IRTuple, memberName string, enums map[string]compiler.IREnumDefinition) ([]string, error) {
+	fields := enum.Fields()
+	if len(tuple.Elements()) != len(fields) {
+		return nil, fmt.Errorf("member '%s' has %d values, but enum '%s' declares %d fields", memberName, len(tuple.Elements()), enum.Name(), len(fields))
+	}
+
+	values := make([]string, len(fields))
+	for i, elt := range tuple.Elements() {
+		fieldType := fields[i].Type()
+		if fieldType.Kind() == compiler.TypeEnum {
+			ref, ok := elt.(compiler.IRReference)
+			target, found := enums[fieldType.Name()]
+			if !ok || !found || ref.Enum() != fieldType.Name() {
+				return nil, fmt.Errorf("field '%s' of member '%s' must reference a member of '%s', got %v", fields[i].Name(), memberName, fieldType.Name(), elt)
+			}
+			values[i] = ref.Enum() + "::" + memberIdent(target, ref.Member())
+			continue
+		}
+
+		t := g.valueTypes[fieldType.String()]
+		value, err := t.primitive.Decode(referencedValue(elt), memberName, i)
+		if err == nil {
+			values[i], err = t.literal(value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error formatting field '%s' of member '%s': %w", fields[i].Name(), memberName, err)
+		}
+	}
+	return values, nil
+}
+
+// referencedValue returns the value a member reference stands for, or v
+// itself if it is not a reference.
+func referencedValue(v compiler.IRValue) compiler.IRValue {
+	if ref, ok := v.(compiler.IRReference); ok {
+		return ref.Target()
+	}
+	return v
+}
+
+// deprecation returns the deprecation notice of a @deprecated declaration
+// named name as a string literal, or "".
+func deprecation(annotations []compiler.IRAnnotation, name string) string {
+	for _, a := range annotations {
+		if a.Name() != "deprecated" {
+			continue
+		}
+		note := name + " should no longer be used."
+		if len(a.Args()) > 0 && a.Args()[0] != "" {
+			note = a.Args()[0]
+		}
+		return `"` + escape(note, '"') + `"`
+	}
+	return ""
+}
+
+// keyString renders a key for error messages.
+func keyString(key any) string {
+	if r, ok := key.(rune); ok {
+		return string(r)
+	}
+	return fmt.Sprint(key)
+}
+
+// dependencies returns the names of the other enums the fields of enum refer to.
+func dependencies(enum compiler.IREnumDefinition) []string {
+	var deps []string
+	for _, field := range enum.Fields() {
+		t := field.Type()
+		if t == nil || t.Kind() != compiler.TypeEnum || t.Name() == enum.Name() {
+			continue
+		}
+		if !slices.Contains(deps, t.Name()) {
+			deps = append(deps, t.Name())
+		}
+	}
+	return deps
+}
@@ -0,0 +1,168 @@
+package rust_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kkumar-gcc/enumgen/src/codegen/internal/testutil"
+)
+
+const sampleSource = testutil.SampleSource + `
+enum HTTPStatus [code int32 unique, text string, status Status, type string]:
+    OK = (200, "OK", Status.SUCCESS, "success"),
+    UNAVAILABLE = (503, "Service {Unavailable}", Status.FAILURE, "error");
+
+enum Ratio [float64]:
+    ONE = 1,
+    HALF = 0.5;
+
+enum Answer [bool, char]:
+    YES = true:'y',
+    NO = false:'n';
+`
+
+func TestGenerate(t *testing.T) {
+	files := testutil.Generate(t, "rust", sampleSource, nil)
+	code, ok := files["enums.rs"]
+	if !ok || len(files) != 1 {
+		t.Fatalf("expected a single enums.rs, got %v", files)
+	}
+
+	testutil.AssertContains(t, code,
+		"//! Enums shared with the services.\n\nuse std::fmt;\nuse std::str::FromStr;\n",
+		"/// Status of an operation.\n#[derive(Debug, Clone, Copy, PartialEq, Eq, Hash)]\npub enum Status {\n    Success,\n",
+		"    /// FAILURE is an alias of [`Status::Error`].\n    pub const FAILURE: Status = Status::Error;",
+		"    pub const fn as_key(&self) -> i64 {",
+		"impl TryFrom<i64> for Status {",
+		"    #[deprecated(note = \"use RED\")]\n    Green,",
+		"#[allow(deprecated)]\nimpl Color {",
+		"            \"red\" | \"crimson\" => Some(Color::Red),",
+		"impl TryFrom<&str> for Color {",
+		"    pub const fn as_value(&self) -> u8 {\n        match self {\n            Day::Monday => 1,",
+		"    pub fn from_value(value: u8) -> Option<Day> {",
+		"    pub const fn code(&self) -> i32 {",
+		"            HTTPStatus::Unavailable => Status::FAILURE,",
+		"    pub const fn r#type(&self) -> &'static str {",
+		"    pub fn from_code(value: i32) -> Option<HTTPStatus> {",
+		"expected one of: OK, UNAVAILABLE\", self.0)",
+		"pub struct Perm(u8);",
+		"    pub const EXEC: Perm = Perm(4);",
+		"                \"WRITE\" | \"W\" => Perm::WRITE,",
+		"    const MASK: u8 = 7;",
+		"            Ratio::One => 1.0,",
+		"            'y' => Some(Answer::Yes),",
+		"            true => Some(Answer::Yes),\n            false => Some(Answer::No),\n        }",
+		"        s.parse::<bool>().ok().and_then(Answer::from_key).ok_or_else",
+	)
+	if strings.Contains(code, "from_text") {
+		t.Errorf("fields not marked unique should not have a lookup")
+	}
+	if strings.Contains(code, "serde") {
+		t.Errorf("serde impls should only be generated on request")
+	}
+
+	code = testutil.Generate(t, "rust", sampleSource, map[string]string{"generate_serde": "true"})["enums.rs"]
+	testutil.AssertContains(t, code,
+		"impl serde::Serialize for Color {",
+		"        let key = <String as serde::Deserialize>::deserialize(deserializer)?;\n        Color::try_from(key.as_str())",
+		"        let key = <i64 as serde::Deserialize>::deserialize(deserializer)?;\n        Status::try_from(key)",
+		"        serde::Serialize::serialize(&self.as_value(), serializer)",
+	)
+}
+
+func TestGenerateCompiles(t *testing.T) {
+	rustc := testutil.LookPath(t, "rustc")
+
+	dir := t.TempDir()
+	code := testutil.Generate(t, "rust", sampleSource+testutil.AliasSource, nil)["enums.rs"]
+	code += `
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn lookups() {
+        assert_eq!(Status::FAILURE, Status::Error);
+        assert_eq!("3".parse::<Status>(), Ok(Status::Error));
+        assert_eq!("crimson".parse::<Color>(), Ok(Color::Red));
+        assert_eq!(Color::Blue.to_string(), "blue");
+        assert_eq!(Day::from_value(2), Some(Day::Tuesday));
+        assert_eq!(HTTPStatus::from_code(503).map(|s| s.status()), Some(Status::Error));
+        assert_eq!("READ | W".parse::<Perm>(), Ok(Perm::READ | Perm::WRITE));
+        assert_eq!((Perm::READ | Perm::EXEC).to_string(), "READ|EXEC");
+        assert_eq!(Perm::from_value(8), None);
+        assert_eq!(Ratio::from_key(0.5), Some(Ratio::Half));
+        assert_eq!(Mode::RUN, Mode::EXEC);
+        assert_eq!("r | w".parse::<Mode>(), Ok(Mode::READ | Mode::WRITE));
+        assert_eq!(Point::ZERO, Point::Origin);
+        assert_eq!(Point::from_x(1), Some(Point::Unit));
+        assert_eq!(Point::from_label("origin"), Some(Point::Origin));
+        assert_eq!(
+            Color::try_from("purple").unwrap_err().to_string(),
+            "invalid Color key \"purple\", expected one of: red, green, blue",
+        );
+    }
+}
+`
+	testutil.WriteFiles(t, dir, map[string]string{"enums.rs": code})
+	testutil.Run(t, dir, rustc, "--edition", "2021", "--test", "-D", "warnings", "-o", "enums", "enums.rs")
+	testutil.Run(t, dir, filepath.Join(dir, "enums"))
+}
+
+func TestGenerateImports(t *testing.T) {
+	files := testutil.Generate(t, "rust", sampleSource, map[string]string{"output_mode": "per_enum"})
+	if len(files) != 7 {
+		t.Fatalf("expected 7 files, got %d", len(files))
+	}
+	testutil.AssertContains(t, files["httpstatus.rs"], "use std::str::FromStr;\n\nuse super::status::Status;\n")
+	if strings.Contains(files["status.rs"], "use super") {
+		t.Errorf("status.rs should not import other enums")
+	}
+	for name, code := range files {
+		if strings.Contains(code, "//! ") {
+			t.Errorf("%s should not repeat the package doc", name)
+		}
+	}
+}
+
+func TestGenerateInvalid(t *testing.T) {
+	tests := map[string]string{
+		"keyword member": `
+enum Receiver [string]:
+    self = "self";
+`,
+		"colliding members": `
+enum Mode [int]:
+    READ_ONLY = 1,
+    ReadOnly = 2;
+`,
+		"generated constant": `
+enum Scope [string]:
+    ONE = "one",
+    EVERYTHING = "all",
+    ALL = EVERYTHING;
+`,
+		"reserved field": `
+enum Column [key string unique, width int]:
+    ID = ("id", 8);
+`,
+		"integer out of range": `
+enum Small [int8]:
+    BIG = 200;
+`,
+	}
+	for name, source := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, err := testutil.Compile(t, "rust", source, nil)
+			if err == nil && len(ctx.Errors) == 0 && !ctx.Validations.HasErrors() {
+				t.Fatalf("expected the enum to be rejected")
+			}
+		})
+	}
+
+	ctx, err := testutil.Compile(t, "rust", sampleSource, map[string]string{"generate_serde": "maybe"})
+	if err == nil && len(ctx.Errors) == 0 {
+		t.Fatalf("expected an invalid generate_serde option to be rejected")
+	}
+}
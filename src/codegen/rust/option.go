@@ -0,0 +1,48 @@
+package rust
+
+const (
+	OptionGenerateSerde = "generate_serde"
+	OptionOutputMode    = "output_mode"
+	OptionFileName      = "file_name"
+)
+
+type OptionDef struct {
+	Key          string
+	DefaultValue string
+	HelpText     string
+}
+
+var allOptions = []OptionDef{
+	{
+		Key:          OptionGenerateSerde,
+		DefaultValue: "false",
+		HelpText:     "If true, implements serde's Serialize and Deserialize, encoding members by their key and flags by their bits.",
+	},
+	{
+		Key:          OptionOutputMode,
+		DefaultValue: "single",
+		HelpText:     "Whether to write one module per EDL source ('single') or one module per enum ('per_enum').",
+	},
+	{
+		Key:          OptionFileName,
+		DefaultValue: "",
+		HelpText:     "The generated file name pattern; {enum} is the lower-cased enum name and {file} the EDL file name (default: '{file}.rs', or '{enum}.rs' in per_enum mode).",
+	},
+}
+
+var (
+	defaultOptions map[string]string
+	optionHelp     map[string]string
+)
+
+func init() {
+	defaultOptions = make(map[string]string)
+	optionHelp = make(map[string]string)
+
+	for _, opt := range allOptions {
+		defaultOptions[opt.Key] = opt.DefaultValue
+		if opt.HelpText != "" {
+			optionHelp[opt.Key] = opt.HelpText
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package rust
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+const (
+	// enumTemplate renders a fieldless Rust enum and its trait impls.
+	enumTemplate = "templates/enum.rs.tmpl"
+
+	// flagsTemplate renders a flags enum as a newtype over its bits, so
+	// that members can be combined.
+	flagsTemplate = "templates/flags.rs.tmpl"
+
+	// fileTemplate wraps the rendered enums with the header, module docs and
+	// imports, so these are emitted once per generated module.
+	fileTemplate = "templates/file.rs.tmpl"
+
+	// partialTemplates define named templates shared by the enum and flags
+	// templates, such as the error type.
+	partialTemplates = "templates/partials/*.tmpl"
+)
+
+var templateFuncs = template.FuncMap{
+	"doc":  doc,
+	"join": strings.Join,
+}
+
+// doc renders text as doc comments using marker, e.g. "///" or "//!". Every
+// line after the first is prefixed with indent so the block lines up with the
+// first line.
+func doc(marker, indent, text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = marker
+		} else {
+			lines[i] = marker + " " + line
+		}
+		if i > 0 {
+			lines[i] = indent + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+type TemplateMember struct {
+	Name         string // member name in EDL
+	Ident        string // variant or associated constant in Rust
+	Doc          string
+	Deprecated   string   // string literal of the deprecation notice from @deprecated, if any
+	Key          string   // literal returned by as_key
+	Value        string   // literal returned by as_value
+	KeyPattern   string   // match arm of from_key, including aliases
+	ValuePattern string   // match arm of from_value, or "" if an earlier member has the same value
+	Fields       []string // expressions of the fields of a tuple member, in field order
+}
+
+// TemplateMemberAlias is a member assigned another member of its enum, which
+// becomes an associated constant holding that member.
+type TemplateMemberAlias struct {
+	Name       string
+	Ident      string
+	Target     string // Rust name of the member it stands for
+	Doc        string
+	Deprecated string // string literal of the deprecation notice, if any
+}
+
+// TemplateField is a named field of a tuple enum.
+type TemplateField struct {
+	Name   string // field name in EDL
+	Method string // accessor method in Rust
+	Type   string // Rust type of the field
+	Unique bool   // whether members can be looked up by the field's value
+
+	// Exhaustive is set if the lookup by the field covers all its values,
+	// e.g. true and false, and needs no fallback arm.
+	Exhaustive bool
+}
+
+// FileData holds everything that appears once per generated module. Enums
+// are the already rendered bodies of the enum templates.
+type FileData struct {
+	EDLVersion  string
+	ToolVersion string
+	Doc         string
+	Imports     []string // paths of the enums declared in sibling modules
+	Enums       []string
+}
+
+type TemplateData struct {
+	EnumName       string
+	EnumDoc        string
+	EnumDeprecated string // string literal of the deprecation notice, if any
+	ErrorName      string // error type returned when parsing fails, e.g. InvalidColor
+	KeyType        string
+	KeyParam       string // parameter type of from_key, which borrows string keys
+	OwnedKeyType   string // type keys are deserialized as, which owns strings
+	ValueType      string
+	ValueParam     string
+	Members        []TemplateMember
+	MemberAliases  []TemplateMemberAlias
+	Fields         []TemplateField
+	IsFlags        bool
+	FlagMask       string // union of all flags, for flags enums
+	HasAliases     bool
+	HasDeprecated  bool   // whether impls have to allow uses of deprecated items
+	ParseKey       string // expression turning the string s into an Option of the enum
+	Serde          bool
+	ValidKeys      string
+
+	// KeysExhaustive and ValuesExhaustive are set if the members cover all
+	// keys or values, e.g. true and false, so the lookups need no fallback arm.
+	KeysExhaustive   bool
+	ValuesExhaustive bool
+}
+
+func loadTemplate(name, path string, fs embed.FS) (*template.Template, error) {
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", path, err)
+	}
+
+	return tmpl, nil
+}
@@ -0,0 +1,94 @@
+package rust
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/kkumar-gcc/enumgen/src/codegen/primitive"
+)
+
+// valueType pairs the Rust type of an EDL primitive with the primitive type
+// that decodes its literals.
+type valueType struct {
+	name      string
+	primitive primitive.Type
+}
+
+// isString reports whether values of the type are string slices.
+func (t valueType) isString() bool { return t.name == "&'static str" }
+
+// defaultValueTypes maps the primitives of the EDL type registry to Rust.
+func defaultValueTypes() map[string]valueType {
+	of := func(edl, rustType string) valueType {
+		return valueType{name: rustType, primitive: primitive.MustLookup(edl)}
+	}
+	return map[string]valueType{
+		"char":    of("char", "char"),
+		"string":  of("string", "&'static str"),
+		"int":     of("int", "i64"),
+		"int8":    of("int8", "i8"),
+		"int32":   of("int32", "i32"),
+		"int64":   of("int64", "i64"),
+		"uint":    of("uint", "u64"),
+		"uint8":   of("uint8", "u8"),
+		"uint32":  of("uint32", "u32"),
+		"uint64":  of("uint64", "u64"),
+		"float":   of("float", "f32"),
+		"float32": of("float32", "f32"),
+		"float64": of("float64", "f64"),
+		"bool":    of("bool", "bool"),
+	}
+}
+
+// literal renders a value decoded by the primitive type of t as a Rust
+// literal.
+func (t valueType) literal(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return `"` + escape(v, '"') + `"`, nil
+	case rune:
+		return `'` + escape(string(v), '\'') + `'`, nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		s := strconv.FormatFloat(v, 'g', -1, t.primitive.BitSize)
+		if strings.ContainsAny(s, "IN") {
+			return "", fmt.Errorf("float %v cannot be written as a Rust literal", v)
+		}
+		if !strings.ContainsAny(s, ".e") {
+			s += ".0"
+		}
+		return s, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		return "", fmt.Errorf("internal error: unsupported value %#v", v)
+	}
+}
+
+// escape escapes s for a Rust string or character literal delimited by quote.
+func escape(s string, quote rune) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch {
+		case r == quote || r == '\\':
+			sb.WriteRune('\\')
+			sb.WriteRune(r)
+		case r == '\n':
+			sb.WriteString(`\n`)
+		case r == '\r':
+			sb.WriteString(`\r`)
+		case r == '\t':
+			sb.WriteString(`\t`)
+		case unicode.IsPrint(r):
+			sb.WriteRune(r)
+		default:
+			fmt.Fprintf(&sb, `\u{%x}`, r)
+		}
+	}
+	return sb.String()
+}
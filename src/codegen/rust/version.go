@@ -0,0 +1,5 @@
+package rust
+
+const (
+	Version = "v0.0.1"
+)
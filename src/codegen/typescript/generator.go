@@ -0,0 +1,543 @@
+package typescript
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/kkumar-gcc/enumgen/pkg/strcase"
+	"github.com/kkumar-gcc/enumgen/src/codegen/contracts"
+	"github.com/kkumar-gcc/enumgen/src/codegen/output"
+	"github.com/kkumar-gcc/enumgen/src/codegen/primitive"
+	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
+	"github.com/kkumar-gcc/enumgen/src/version"
+)
+
+var _ contracts.Generator = (*Generator)(nil)
+
+// maxFlagBit is the highest bit a flag may use, as TypeScript bitwise
+// operators work on signed 32-bit integers.
+const maxFlagBit = 30
+
+type Generator struct {
+	templates  map[Style]*template.Template
+	file       *template.Template
+	valueTypes map[string]valueType
+}
+
+func New() (*Generator, error) {
+	templates, err := LoadTemplates(defaultTemplates, templatesFS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load templates: %w", err)
+	}
+	file, err := loadTemplate("file", fileTemplate, templatesFS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	return &Generator{
+		templates:  templates,
+		file:       file,
+		valueTypes: defaultValueTypes(),
+	}, nil
+}
+
+func (g *Generator) Name() string {
+	return "TypeScript"
+}
+
+func (g *Generator) Language() string {
+	return "typescript"
+}
+
+func (g *Generator) DefaultOptions() map[string]string {
+	return defaultOptions
+}
+
+func (g *Generator) OptionHelp() string {
+	sb := strings.Builder{}
+	sb.WriteString("Available options for " + g.Name() + " code generation:\n")
+	for key, value := range g.DefaultOptions() {
+		help := optionHelp[key]
+		if help == "" {
+			sb.WriteString(fmt.Sprintf("  - %s (default: %s)\n", key, value))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("  - %s: %s (default: %s)\n", key, help, value))
+	}
+	return sb.String()
+}
+
+func (g *Generator) Generate(module compiler.IRModule, options map[string]string) ([]*compiler.OutputFile, error) {
+	opts := maps.Clone(g.DefaultOptions())
+	maps.Copy(opts, options)
+
+	outputMode, pattern, err := output.Resolve(opts[OptionOutputMode], opts[OptionFileName], ".gen.ts")
+	if err != nil {
+		return nil, err
+	}
+
+	style := ParseStyle(opts[OptionEnumStyle])
+	if style == StyleUnknown {
+		return nil, fmt.Errorf("unknown enum style '%s'", opts[OptionEnumStyle])
+	}
+
+	enums := make(map[string]string, len(module.Enums()))
+	for _, enum := range module.Enums() {
+		code, err := g.generateEnum(enum, style)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate code for enum '%s': %w", enum.Name(), err)
+		}
+		enums[enum.Name()] = code
+	}
+
+	if outputMode == output.Single {
+		if len(enums) == 0 {
+			return nil, nil
+		}
+
+		// Field tables refer to the members of other enums when the module is
+		// loaded, so those enums have to be declared first.
+		ordered := orderEnums(module.Enums())
+		bodies := make([]string, len(ordered))
+		for i, enum := range ordered {
+			bodies[i] = enums[enum.Name()]
+		}
+
+		fileName := output.FileName(pattern, module.Name(), "")
+		code, err := g.generateFile(module.Doc(), nil, bodies)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate file '%s': %w", fileName, err)
+		}
+		return []*compiler.OutputFile{{Path: fileName, Body: code}}, nil
+	}
+
+	fileNames, err := output.FileNames(pattern, module)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*compiler.OutputFile, 0, len(enums))
+	for _, enum := range module.Enums() {
+		fileName := fileNames[enum.Name()]
+
+		var imports []Import
+		for _, dep := range dependencies(enum) {
+			path := filepath.Base(fileNames[dep])
+			imports = append(imports, Import{
+				Names: []string{dep},
+				Path:  "./" + strings.TrimSuffix(path, filepath.Ext(path)),
+			})
+		}
+
+		// The package doc describes the module as a whole rather than any one
+		// enum, so it is only written to the single file.
+		code, err := g.generateFile("", imports, []string{enums[enum.Name()]})
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate code for enum '%s': %w", enum.Name(), err)
+		}
+
+		files = append(files, &compiler.OutputFile{
+			Path: fileName,
+			Body: code,
+		})
+	}
+
+	return files, nil
+}
+
+// generateFile wraps rendered enums with the file header and imports.
+func (g *Generator) generateFile(fileDoc string, imports []Import, enums []string) ([]byte, error) {
+	data := FileData{
+		EDLVersion:  version.Version,
+		ToolVersion: Version,
+		FileDoc:     fileDoc,
+		Imports:     imports,
+		Enums:       enums,
+	}
+
+	var buf bytes.Buffer
+	if err := g.file.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return tidySource(buf.Bytes()), nil
+}
+
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+// tidySource collapses runs of blank lines left by the templates and ends the
+// source with a single newline.
+func tidySource(src []byte) []byte {
+	src = blankLines.ReplaceAll(src, []byte("\n\n"))
+	return append(bytes.TrimRight(src, "\n"), '\n')
+}
+
+// generateEnum renders the declarations of a single enum, without the file
+// header and imports.
+func (g *Generator) generateEnum(enum compiler.IREnumDefinition, style Style) (string, error) {
+	data, err := g.prepareTemplateData(enum, style)
+	if err != nil {
+		return "", fmt.Errorf("failed to prepare data: %w", err)
+	}
+
+	tmpl, ok := g.templates[style]
+	if !ok {
+		return "", fmt.Errorf("template for style '%s' not found", style)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+func (g *Generator) prepareTemplateData(enum compiler.IREnumDefinition, style Style) (*TemplateData, error) {
+	fields, err := g.prepareFields(enum)
+	if err != nil {
+		return nil, err
+	}
+
+	valueTypeName := "string" // tuple members are keyed by their names
+	if fields == nil {
+		valueType := enum.ValueType()
+		if valueType == nil {
+			return nil, fmt.Errorf("enum '%s' has no value type defined", enum.Name())
+		}
+		valueTypeName = valueType.String()
+	}
+	valueType, ok := g.valueTypes[valueTypeName]
+	if !ok {
+		return nil, fmt.Errorf("unsupported value type '%s' for enum '%s'", valueTypeName, enum.Name())
+	}
+
+	keyType := valueType
+	if kt := enum.KeyType(); kt != nil {
+		if keyType, ok = g.valueTypes[kt.String()]; !ok {
+			return nil, fmt.Errorf("unsupported key type '%s' for enum '%s'", kt.String(), enum.Name())
+		}
+	}
+
+	if enum.IsFlags() {
+		// Flag sets are keyed by their member names, e.g. "READ|WRITE".
+		if valueType.primitive.Kind != primitive.Int {
+			return nil, fmt.Errorf("flags enum '%s' requires an integer value type, got '%s'", enum.Name(), valueTypeName)
+		}
+		keyType = g.valueTypes["string"]
+	}
+	if style == StyleEnum && keyType.name != "string" && keyType.name != "number" {
+		return nil, fmt.Errorf("enum style '%s' requires string or numeric keys, but '%s' has %s keys", style, enum.Name(), keyType.name)
+	}
+
+	data := &TemplateData{
+		Style:          style,
+		EnumName:       enum.Name(),
+		EnumDoc:        enum.Doc(),
+		EnumDeprecated: deprecation(enum.Annotations(), enum.Name()),
+		KeyType:        keyType.name,
+		ValueType:      valueType.name,
+		Fields:         fields,
+		IsFlags:        enum.IsFlags(),
+		HasValues:      enum.KeyType() != nil && len(enum.Members()) > 0,
+	}
+
+	keys := make([]string, 0, len(enum.Members()))
+	var mask uint64
+	for i, member := range enum.Members() {
+		var keyIR, valueIR compiler.IRValue
+		var fieldValues []string
+
+		switch v := member.Value().(type) {
+		case compiler.IRKeyValue:
+			keyIR = referencedValue(v.Key())
+			valueIR = referencedValue(v.Value())
+		case compiler.IRTuple:
+			fieldValues, err = g.formatTuple(enum, v, member.Name())
+			if err != nil {
+				return nil, err
+			}
+		default:
+			keyIR = referencedValue(member.Value())
+			valueIR = keyIR
+		}
+
+		var key any = member.Name()
+		if !enum.IsFlags() {
+			key, err = keyType.primitive.Decode(keyIR, member.Name(), i)
+			if err != nil {
+				return nil, fmt.Errorf("error formatting key for member '%s': %w", member.Name(), err)
+			}
+		}
+		value, err := valueType.primitive.Decode(valueIR, member.Name(), i)
+		if err != nil {
+			return nil, fmt.Errorf("error formatting value for member '%s': %w", member.Name(), err)
+		}
+
+		if enum.IsFlags() {
+			bit, err := flagBit(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid flag '%s': %w", member.Name(), err)
+			}
+			if bit > maxFlagBit {
+				return nil, fmt.Errorf("flag '%s' uses bit %d, but TypeScript bitwise operators only cover bits 0 to %d", member.Name(), bit, maxFlagBit)
+			}
+			mask |= 1 << bit
+		}
+
+		keyLiteral, err := keyType.literal(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key for member '%s': %w", member.Name(), err)
+		}
+		valueLiteral, err := valueType.literal(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for member '%s': %w", member.Name(), err)
+		}
+
+		aliases := member.Aliases()
+		if len(aliases) > 0 && keyType.name != "string" {
+			return nil, fmt.Errorf("member '%s' declares aliases, but the key type is '%s'", member.Name(), keyType.name)
+		}
+		lookups := []string{keyLiteral}
+		for _, alias := range aliases {
+			lookups = append(lookups, quote(alias))
+		}
+		data.HasAliases = data.HasAliases || len(aliases) > 0
+
+		declared := keyLiteral
+		if enum.IsFlags() {
+			declared = valueLiteral
+		}
+
+		data.Members = append(data.Members, TemplateMember{
+			Name:       member.Name(),
+			Doc:        member.Doc(),
+			Deprecated: deprecation(member.Annotations(), member.Name()),
+			Literal:    declared,
+			Key:        keyLiteral,
+			Value:      valueLiteral,
+			Lookups:    lookups,
+			Fields:     fieldValues,
+		})
+		keys = append(keys, keyString(key))
+	}
+
+	data.FlagMask = fmt.Sprint(mask)
+	data.ValidKeys = "one of: " + strings.Join(keys, ", ")
+
+	aliases, err := memberAliases(enum, style, data.Members)
+	if err != nil {
+		return nil, err
+	}
+	data.MemberAliases = aliases
+
+	return data, nil
+}
+
+// prepareFields returns the fields of a tuple enum, or nil for other enums.
+func (g *Generator) prepareFields(enum compiler.IREnumDefinition) ([]TemplateField, error) {
+	if len(enum.Fields()) == 0 {
+		return nil, nil
+	}
+
+	fields := make([]TemplateField, 0, len(enum.Fields()))
+	owners := make(map[string]string)
+	for _, field := range enum.Fields() {
+		if field.Type() == nil {
+			return nil, fmt.Errorf("field '%s' of enum '%s' has no type", field.Name(), enum.Name())
+		}
+
+		typeName := field.Type().Name()
+		if field.Type().Kind() != compiler.TypeEnum {
+			t, ok := g.valueTypes[field.Type().String()]
+			if !ok {
+				return nil, fmt.Errorf("unsupported type '%s' of field '%s' in enum '%s'", field.Type().String(), field.Name(), enum.Name())
+			}
+			typeName = t.name
+		}
+
+		accessor := strcase.ToPascal(field.Name())
+		if prev, ok := owners[accessor]; ok {
+			return nil, fmt.Errorf("fields '%s' and '%s' of enum '%s' both map to '%s'", prev, field.Name(), enum.Name(), accessor)
+		}
+		owners[accessor] = field.Name()
+
+		fields = append(fields, TemplateField{
+			Name:     field.Name(),
+			Accessor: accessor,
+			Type:     typeName,
+			Unique:   field.IsUnique(),
+		})
+	}
+	return fields, nil
+}
+
+// formatTuple renders the values of a tuple member in field order. Fields of
+// an enum type hold references to its members.
+func (g *Generator) formatTuple(enum compiler.IREnumDefinition, tuple compiler.IRTuple, memberName string) ([]string, error) {
+	fields := enum.Fields()
+	if len(tuple.Elements()) != len(fields) {
+		return nil, fmt.Errorf("member '%s' has %d values, but enum '%s' declares %d fields", memberName, len(tuple.Elements()), enum.Name(), len(fields))
+	}
+
+	values := make([]string, len(fields))
+	for i, elt := range tuple.Elements() {
+		fieldType := fields[i].Type()
+		if fieldType.Kind() == compiler.TypeEnum {
+			ref, ok := elt.(compiler.IRReference)
+			if !ok || ref.Enum() != fieldType.Name() {
+				return nil, fmt.Errorf("field '%s' of member '%s' must reference a member of '%s', got %v", fields[i].Name(), memberName, fieldType.Name(), elt)
+			}
+			values[i] = ref.Enum() + "." + ref.Member()
+			continue
+		}
+
+		t := g.valueTypes[fieldType.String()]
+		value, err := t.primitive.Decode(referencedValue(elt), memberName, i)
+		if err == nil {
+			values[i], err = t.literal(value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error formatting field '%s' of member '%s': %w", fields[i].Name(), memberName, err)
+		}
+	}
+	return values, nil
+}
+
+// memberAliases returns the members of the enum that alias another member.
+// Const objects cannot refer to their own properties, so there aliases repeat
+// the literal of their target.
+func memberAliases(enum compiler.IREnumDefinition, style Style, members []TemplateMember) ([]TemplateMemberAlias, error) {
+	aliases := make([]TemplateMemberAlias, 0, len(enum.MemberAliases()))
+	for _, member := range enum.MemberAliases() {
+		ref, ok := member.Value().(compiler.IRReference)
+		if !ok {
+			return nil, fmt.Errorf("member '%s' is not assigned another member", member.Name())
+		}
+		i := slices.IndexFunc(members, func(m TemplateMember) bool { return m.Name == ref.Member() })
+		if i < 0 {
+			return nil, fmt.Errorf("member '%s' aliases unknown member '%s'", member.Name(), ref.Member())
+		}
+
+		value := members[i].Literal
+		if style == StyleEnum {
+			value = ref.Member()
+		}
+
+		aliases = append(aliases, TemplateMemberAlias{
+			Name:       member.Name(),
+			Target:     ref.Member(),
+			Value:      value,
+			Doc:        member.Doc(),
+			Deprecated: deprecation(member.Annotations(), member.Name()),
+		})
+	}
+	return aliases, nil
+}
+
+// referencedValue returns the value a member reference stands for, or v
+// itself if it is not a reference.
+func referencedValue(v compiler.IRValue) compiler.IRValue {
+	if ref, ok := v.(compiler.IRReference); ok {
+		return ref.Target()
+	}
+	return v
+}
+
+// deprecation returns the deprecation notice of a @deprecated declaration named name, or "".
+func deprecation(annotations []compiler.IRAnnotation, name string) string {
+	for _, a := range annotations {
+		if a.Name() != "deprecated" {
+			continue
+		}
+		if len(a.Args()) > 0 && a.Args()[0] != "" {
+			return a.Args()[0]
+		}
+		return name + " should no longer be used."
+	}
+	return ""
+}
+
+// flagBit returns the index of the single bit set in a flag value.
+func flagBit(value any) (int, error) {
+	var v uint64
+	switch n := value.(type) {
+	case int64:
+		if n <= 0 {
+			return 0, fmt.Errorf("value %d is not a single bit", n)
+		}
+		v = uint64(n)
+	case uint64:
+		v = n
+	default:
+		return 0, fmt.Errorf("internal error: unexpected flag value %#v", value)
+	}
+	if v == 0 || v&(v-1) != 0 {
+		return 0, fmt.Errorf("value %d is not a single bit", v)
+	}
+
+	bit := 0
+	for v > 1 {
+		v >>= 1
+		bit++
+	}
+	return bit, nil
+}
+
+// keyString renders a key for error messages.
+func keyString(key any) string {
+	if r, ok := key.(rune); ok {
+		return string(r)
+	}
+	return fmt.Sprint(key)
+}
+
+// dependencies returns the names of the other enums the fields of enum refer to.
+func dependencies(enum compiler.IREnumDefinition) []string {
+	var deps []string
+	for _, field := range enum.Fields() {
+		t := field.Type()
+		if t == nil || t.Kind() != compiler.TypeEnum || t.Name() == enum.Name() {
+			continue
+		}
+		if !slices.Contains(deps, t.Name()) {
+			deps = append(deps, t.Name())
+		}
+	}
+	return deps
+}
+
+// orderEnums returns the enums in declaration order, except that every enum
+// comes after the enums its fields refer to.
+func orderEnums(enums []compiler.IREnumDefinition) []compiler.IREnumDefinition {
+	byName := make(map[string]compiler.IREnumDefinition, len(enums))
+	for _, enum := range enums {
+		byName[enum.Name()] = enum
+	}
+
+	ordered := make([]compiler.IREnumDefinition, 0, len(enums))
+	visited := make(map[string]bool, len(enums))
+	var visit func(enum compiler.IREnumDefinition)
+	visit = func(enum compiler.IREnumDefinition) {
+		if visited[enum.Name()] {
+			return
+		}
+		visited[enum.Name()] = true
+		for _, dep := range dependencies(enum) {
+			if d, ok := byName[dep]; ok {
+				visit(d)
+			}
+		}
+		ordered = append(ordered, enum)
+	}
+	for _, enum := range enums {
+		visit(enum)
+	}
+	return ordered
+}
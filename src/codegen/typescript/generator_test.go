@@ -0,0 +1,251 @@
+package typescript_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kkumar-gcc/enumgen/src/codegen/internal/testutil"
+)
+
+const sampleSource = testutil.SampleSource
+
+func TestGenerateConstStyle(t *testing.T) {
+	files := testutil.Generate(t, "typescript", sampleSource, nil)
+	if len(files) != 4 {
+		t.Fatalf("expected 4 files, got %d", len(files))
+	}
+
+	testutil.AssertContains(t, files["status.gen.ts"],
+		"/** Status of an operation. */\nexport const Status = {",
+		"  SUCCESS: 1,\n",
+		"  FAILURE: 3,\n} as const;",
+		"export type Status = (typeof Status)[keyof typeof Status];",
+		"export const StatusMembers: readonly Status[] = [\n  Status.SUCCESS,\n  Status.WARNING,\n  Status.ERROR,\n];",
+		"export function isStatus(x: unknown): x is Status {",
+		"export function parseStatusKey(key: number): Status {",
+		`", expected one of: 1, 2, 3"`,
+	)
+	testutil.AssertContains(t, files["color.gen.ts"],
+		"  /** @deprecated use RED */\n  GREEN: \"green\",",
+		`  ["crimson", Color.RED],`,
+		"maps the keys of Color, including aliases, to its members",
+	)
+	testutil.AssertContains(t, files["day.gen.ts"],
+		`  MONDAY: "Monday",`,
+		"export const DayValueMap: ReadonlyMap<number, Day> = new Map<number, Day>([\n  [1, Day.MONDAY],",
+		"export function valueOfDay(member: Day): number {\n  switch (member) {\n    case Day.MONDAY:\n      return 1;",
+		"export function parseDayValue(value: number): Day {",
+	)
+	if strings.Contains(files["color.gen.ts"], "valueOfColor") {
+		t.Errorf("single-typed enums should not have a separate value lookup")
+	}
+}
+
+func TestGenerateEnumStyle(t *testing.T) {
+	files := testutil.Generate(t, "typescript", sampleSource, map[string]string{"enum_style": "enum"})
+
+	testutil.AssertContains(t, files["status.gen.ts"],
+		"export enum Status {\n  SUCCESS = 1,\n  WARNING = 2,\n  ERROR = 3,\n  FAILURE = ERROR,\n}",
+		"export function isStatus(x: unknown): x is Status {",
+	)
+	testutil.AssertContains(t, files["color.gen.ts"], "export enum Color {\n  RED = \"red\",")
+	if strings.Contains(files["status.gen.ts"], "export type Status") {
+		t.Errorf("native enums should not declare a separate union type")
+	}
+
+	source := `
+enum Answer [bool, string]:
+    YES = true:"y",
+    NO = false:"n";
+`
+	ctx, err := testutil.Compile(t, "typescript", source, map[string]string{"enum_style": "enum"})
+	if err == nil && len(ctx.Errors) == 0 {
+		t.Fatalf("expected native enums with boolean keys to be rejected")
+	}
+
+	files = testutil.Generate(t, "typescript", source, nil)
+	testutil.AssertContains(t, files["answer.gen.ts"], "  YES: true,", "ReadonlyMap<boolean, Answer>")
+}
+
+func TestGenerateFlags(t *testing.T) {
+	source := `
+enum Perm [uint8] flags:
+    READ,
+    WRITE alias "W",
+    EXEC = 0x4;
+`
+	for _, style := range []string{"const", "enum"} {
+		t.Run(style, func(t *testing.T) {
+			files := testutil.Generate(t, "typescript", source, map[string]string{"enum_style": style})
+			code := files["perm.gen.ts"]
+
+			testutil.AssertContains(t, code,
+				"READ",
+				`  ["W", Perm.WRITE],`,
+				"x <= 7 && (x & ~7) === 0",
+				`export function parsePermKey(key: string): Perm {`,
+				`export function keyOfPerm(flags: Perm): string {`,
+			)
+			if style == "const" {
+				testutil.AssertContains(t, code, "  EXEC: 4,\n} as const;", "export type Perm = number;")
+			} else {
+				testutil.AssertContains(t, code, "  EXEC = 4,\n}")
+			}
+		})
+	}
+
+	source = `
+enum Wide [uint64] flags:
+    LOW,
+    HIGH = 0x10000000000;
+`
+	ctx, err := testutil.Compile(t, "typescript", source, nil)
+	if err == nil && len(ctx.Errors) == 0 {
+		t.Fatalf("expected flags beyond 31 bits to be rejected")
+	}
+}
+
+func TestGenerateTuples(t *testing.T) {
+	source := `
+enum HTTPStatus [code int unique, text string, status Status, retryable bool]:
+    OK = (200, "OK", Status.OK, false),
+    UNAVAILABLE = (503, "Service Unavailable", Status.FAILURE, true);
+
+enum Status [int]:
+    OK = 0,
+    ERROR = 1,
+    FAILURE = ERROR;
+`
+	files := testutil.Generate(t, "typescript", source, nil)
+	code := files["httpstatus.gen.ts"]
+	testutil.AssertContains(t, code,
+		`import { Status } from "./status.gen";`,
+		"export interface HTTPStatusFields {\n  readonly code: number;\n  readonly text: string;\n  readonly status: Status;\n  readonly retryable: boolean;\n}",
+		`  [HTTPStatus.UNAVAILABLE]: { code: 503, text: "Service Unavailable", status: Status.FAILURE, retryable: true },`,
+		"export function fieldsOfHTTPStatus(member: HTTPStatus): HTTPStatusFields {",
+		"  [503, HTTPStatus.UNAVAILABLE],",
+		"export function parseHTTPStatusByCode(value: number): HTTPStatus {",
+	)
+	if strings.Contains(code, "parseHTTPStatusByText") {
+		t.Errorf("fields not marked unique should not have a lookup")
+	}
+
+	// In a single file the field table refers to Status when it is loaded,
+	// so Status has to be declared first.
+	single := testutil.Generate(t, "typescript", source, map[string]string{"output_mode": "single"})["enums.gen.ts"]
+	if strings.Contains(single, "import") {
+		t.Errorf("single files should not import their own enums:\n%s", single)
+	}
+	if strings.Index(single, "export const Status =") > strings.Index(single, "export const HTTPStatus =") {
+		t.Errorf("Status should be declared before HTTPStatus:\n%s", single)
+	}
+}
+
+func TestGenerateAliases(t *testing.T) {
+	for _, style := range []string{"const", "enum"} {
+		t.Run(style, func(t *testing.T) {
+			files := testutil.Generate(t, "typescript", testutil.AliasSource, map[string]string{"enum_style": style})
+
+			testutil.AssertContains(t, files["mode.gen.ts"],
+				"export const ModeMembers: readonly Mode[] = [\n  Mode.READ,\n  Mode.WRITE,\n  Mode.EXEC,\n];",
+				"  [\"READ\", Mode.READ],\n  [\"r\", Mode.READ],\n  [\"WRITE\", Mode.WRITE],\n  [\"w\", Mode.WRITE],\n  [\"EXEC\", Mode.EXEC],\n]);",
+				"x <= 7 && (x & ~7) === 0",
+			)
+			testutil.AssertContains(t, files["point.gen.ts"],
+				"  [Point.ORIGIN]: { x: 0, y: 0, label: \"origin\" },",
+				"export function parsePointByX(value: number): Point {",
+				"export function parsePointByLabel(value: string): Point {",
+				`", expected one of: ORIGIN, UNIT"`,
+			)
+			if style == "const" {
+				testutil.AssertContains(t, files["mode.gen.ts"], "  EXEC: 4,\n  RUN: 4,\n} as const;")
+				testutil.AssertContains(t, files["point.gen.ts"], "  ZERO: \"ORIGIN\",\n} as const;")
+			} else {
+				testutil.AssertContains(t, files["mode.gen.ts"], "  EXEC = 4,\n  RUN = EXEC,\n}")
+				testutil.AssertContains(t, files["point.gen.ts"], "  ZERO = ORIGIN,\n}")
+			}
+			if strings.Contains(files["point.gen.ts"], "parsePointByY") {
+				t.Errorf("fields not marked unique should not have a lookup")
+			}
+		})
+	}
+}
+
+func TestGenerateCompiles(t *testing.T) {
+	tsc := testutil.LookPath(t, "tsc")
+
+	source := sampleSource + testutil.AliasSource + `
+enum HTTPStatus [code int unique, text string, status Status, retryable bool]:
+    OK = (200, "OK", Status.SUCCESS, false),
+    UNAVAILABLE = (503, "Service Unavailable", Status.FAILURE, true);
+`
+	for _, style := range []string{"const", "enum"} {
+		t.Run(style, func(t *testing.T) {
+			dir := t.TempDir()
+			paths := testutil.WriteFiles(t, dir, testutil.Generate(t, "typescript", source, map[string]string{"enum_style": style}))
+			args := append([]string{"--noEmit", "--strict", "--target", "es2020"}, paths...)
+			testutil.Run(t, dir, tsc, args...)
+		})
+	}
+}
+
+func TestGenerateDocComments(t *testing.T) {
+	source := `// Enums shared with the frontend.
+
+// Color of a widget.
+// It is shown in the UI.
+@deprecated
+enum Color [string]:
+    RED = "red", // the default
+    BLUE = "blue";
+`
+	code := testutil.Generate(t, "typescript", source, nil)["color.gen.ts"]
+	if strings.Contains(code, "Enums shared with the frontend.") {
+		t.Errorf("expected the package doc to be left out of per-enum files, got:\n%s", code)
+	}
+	single := testutil.Generate(t, "typescript", source, map[string]string{"output_mode": "single"})["enums.gen.ts"]
+	testutil.AssertContains(t, single, "// Enums shared with the frontend.\n")
+	testutil.AssertContains(t, code,
+		"/**\n * Color of a widget.\n * It is shown in the UI.\n *\n * @deprecated Color should no longer be used.\n */\nexport const Color",
+		"  /** the default */\n  RED: \"red\",",
+	)
+}
+
+func TestGenerateInvalidValues(t *testing.T) {
+	tests := map[string]string{
+		"unsafe integer": `
+enum Big [int64]:
+    HUGE = 9007199254740993;
+`,
+		"unsafe key": `
+enum Big [uint64, string]:
+    HUGE = 18446744073709551615:"huge";
+`,
+	}
+	for name, source := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, err := testutil.Compile(t, "typescript", source, nil)
+			if err == nil && len(ctx.Errors) == 0 {
+				t.Fatalf("expected integers beyond the safe range to be rejected")
+			}
+		})
+	}
+}
+
+func TestGenerateOptions(t *testing.T) {
+	files := testutil.Generate(t, "typescript", sampleSource, map[string]string{"output_mode": "single", "file_name": "{file}.ts"})
+	if _, ok := files["enums.ts"]; !ok || len(files) != 1 {
+		t.Fatalf("expected a single enums.ts, got %v", files)
+	}
+
+	for _, options := range []map[string]string{
+		{"enum_style": "union"},
+		{"output_mode": "both"},
+		{"output_mode": "single", "file_name": "{enum}.ts"},
+	} {
+		ctx, err := testutil.Compile(t, "typescript", sampleSource, options)
+		if err == nil && len(ctx.Errors) == 0 {
+			t.Errorf("expected options %v to be rejected", options)
+		}
+	}
+}
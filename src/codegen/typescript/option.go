@@ -0,0 +1,48 @@
+package typescript
+
+const (
+	OptionEnumStyle  = "enum_style"
+	OptionOutputMode = "output_mode"
+	OptionFileName   = "file_name"
+)
+
+type OptionDef struct {
+	Key          string
+	DefaultValue string
+	HelpText     string
+}
+
+var allOptions = []OptionDef{
+	{
+		Key:          OptionEnumStyle,
+		DefaultValue: "const",
+		HelpText:     "How members are declared: 'const' for a const object and a union type, or 'enum' for a native TypeScript enum.",
+	},
+	{
+		Key:          OptionOutputMode,
+		DefaultValue: "per_enum",
+		HelpText:     "Whether to write one file per enum ('per_enum') or one file per EDL source ('single').",
+	},
+	{
+		Key:          OptionFileName,
+		DefaultValue: "",
+		HelpText:     "The generated file name pattern; {enum} is the lower-cased enum name and {file} the EDL file name (default: '{enum}.gen.ts', or '{file}.gen.ts' in single mode).",
+	},
+}
+
+var (
+	defaultOptions map[string]string
+	optionHelp     map[string]string
+)
+
+func init() {
+	defaultOptions = make(map[string]string)
+	optionHelp = make(map[string]string)
+
+	for _, opt := range allOptions {
+		defaultOptions[opt.Key] = opt.DefaultValue
+		if opt.HelpText != "" {
+			optionHelp[opt.Key] = opt.HelpText
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package typescript
+
+type Style string
+
+const (
+	// StyleConst generates a const object of the members and a union type of their keys
+	StyleConst Style = "const"
+
+	// StyleEnum generates a native TypeScript enum. It requires string or numeric keys.
+	StyleEnum Style = "enum"
+
+	// StyleUnknown is used for unrecognized styles
+	StyleUnknown Style = "unknown"
+)
+
+func (s Style) String() string {
+	return string(s)
+}
+
+func ParseStyle(style string) Style {
+	switch style {
+	case string(StyleConst):
+		return StyleConst
+	case string(StyleEnum):
+		return StyleEnum
+	default:
+		return StyleUnknown
+	}
+}
@@ -0,0 +1,176 @@
+package typescript
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// partialTemplates are shared by all styles and define named templates, such
+// as "lookups", which the style templates include.
+const partialTemplates = "templates/partials/*.tmpl"
+
+var defaultTemplates = map[Style]string{
+	StyleConst: "templates/const.ts.tmpl",
+	StyleEnum:  "templates/enum.ts.tmpl",
+}
+
+// fileTemplate wraps the rendered enums with the header and imports, so these
+// are emitted once per generated file.
+const fileTemplate = "templates/file.ts.tmpl"
+
+var templateFuncs = template.FuncMap{
+	"comment": comment,
+	"jsdoc":   jsdoc,
+	"join":    strings.Join,
+	"quote":   quote,
+}
+
+// comment renders text as TypeScript line comments.
+func comment(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = "//"
+		} else {
+			lines[i] = "// " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// jsdoc renders text as a JSDoc comment, followed by a @deprecated tag if
+// deprecated is set. Every line after the first is prefixed with indent so the
+// block lines up with the first line.
+func jsdoc(indent, text, deprecated string) string {
+	var lines []string
+	if text != "" {
+		lines = strings.Split(text, "\n")
+	}
+	if deprecated != "" {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "@deprecated "+deprecated)
+	}
+	for i, line := range lines {
+		lines[i] = strings.ReplaceAll(line, "*/", "*\\/")
+	}
+
+	if len(lines) == 1 {
+		return "/** " + lines[0] + " */"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("/**\n")
+	for _, line := range lines {
+		sb.WriteString(indent + " *")
+		if line != "" {
+			sb.WriteString(" " + line)
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(indent + " */")
+	return sb.String()
+}
+
+type TemplateMember struct {
+	Name       string
+	Doc        string
+	Deprecated string   // deprecation notice from @deprecated, if any
+	Literal    string   // literal the member is declared with: its key, or its bit in flag enums
+	Key        string   // TypeScript literal of the key
+	Value      string   // TypeScript literal of the value
+	Lookups    []string // literals of the keys accepted when parsing, including aliases
+	Fields     []string // expressions of the fields of a tuple member, in field order
+}
+
+// TemplateMemberAlias is a member assigned another member of its enum, which
+// becomes a second name for that member.
+type TemplateMemberAlias struct {
+	Name       string
+	Target     string // name of the aliased member
+	Value      string // expression the alias is initialized with
+	Doc        string
+	Deprecated string
+}
+
+// TemplateField is a named field of a tuple enum.
+type TemplateField struct {
+	Name     string // field name in EDL and property name in TypeScript
+	Accessor string // PascalCase name used in lookup functions
+	Type     string // TypeScript type of the field
+	Unique   bool   // whether members can be parsed from the field's value
+}
+
+// Import is a generated file declaring enums that the fields of another
+// enum refer to.
+type Import struct {
+	Names []string
+	Path  string
+}
+
+// FileData holds everything that appears once per generated file. Enums are
+// the already rendered bodies of the enum templates.
+type FileData struct {
+	EDLVersion  string
+	ToolVersion string
+	FileDoc     string
+	Imports     []Import
+	Enums       []string
+}
+
+type TemplateData struct {
+	Style          Style
+	EnumName       string
+	EnumDoc        string
+	EnumDeprecated string
+	KeyType        string
+	ValueType      string
+	Members        []TemplateMember
+	MemberAliases  []TemplateMemberAlias
+	Fields         []TemplateField
+	IsFlags        bool
+	FlagMask       string // literal of all flags combined
+	HasValues      bool   // whether members carry a value apart from their key
+	HasAliases     bool
+	ValidKeys      string
+}
+
+// LoadTemplates loads the TypeScript templates from the embedded filesystem
+// and returns a map of Style to *template.Template.
+func LoadTemplates(templates map[Style]string, fs embed.FS) (map[Style]*template.Template, error) {
+	loadedTemplates := make(map[Style]*template.Template)
+
+	for style, path := range templates {
+		tmpl, err := loadTemplate(style.String(), path, fs)
+		if err != nil {
+			return nil, err
+		}
+		if tmpl, err = tmpl.ParseFS(fs, partialTemplates); err != nil {
+			return nil, fmt.Errorf("failed to parse partial templates: %w", err)
+		}
+
+		loadedTemplates[style] = tmpl
+	}
+
+	return loadedTemplates, nil
+}
+
+func loadTemplate(name, path string, fs embed.FS) (*template.Template, error) {
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", path, err)
+	}
+
+	return tmpl, nil
+}
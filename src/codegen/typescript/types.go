@@ -0,0 +1,91 @@
+package typescript
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/kkumar-gcc/enumgen/src/codegen/primitive"
+)
+
+// maxSafeInteger is the largest integer a TypeScript number holds exactly.
+const maxSafeInteger = 1<<53 - 1
+
+// valueType pairs the TypeScript type of an EDL type with the primitive type
+// that decodes its literals.
+type valueType struct {
+	name      string
+	primitive primitive.Type
+}
+
+func defaultValueTypes() map[string]valueType {
+	number := func(edl string) valueType {
+		return valueType{name: "number", primitive: primitive.MustLookup(edl)}
+	}
+	return map[string]valueType{
+		"char":    {name: "string", primitive: primitive.MustLookup("char")},
+		"string":  {name: "string", primitive: primitive.MustLookup("string")},
+		"int":     number("int"),
+		"int8":    number("int8"),
+		"int16":   number("int16"),
+		"int32":   number("int32"),
+		"rune":    number("rune"),
+		"int64":   number("int64"),
+		"uint":    number("uint"),
+		"uint8":   number("uint8"),
+		"byte":    number("byte"),
+		"uint16":  number("uint16"),
+		"uint32":  number("uint32"),
+		"uint64":  number("uint64"),
+		"float":   number("float"),
+		"float32": number("float32"),
+		"float64": number("float64"),
+		"bool":    {name: "boolean", primitive: primitive.MustLookup("bool")},
+	}
+}
+
+// literal renders a value decoded by the primitive type of t as a TypeScript
+// literal. Integers beyond the safe range of a number are rejected, as they
+// would silently lose precision.
+func (t valueType) literal(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return quote(v), nil
+	case rune:
+		return quote(string(v)), nil
+	case int64:
+		if v > maxSafeInteger || v < -maxSafeInteger {
+			return "", fmt.Errorf("integer %d cannot be represented exactly by a TypeScript number", v)
+		}
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		if v > maxSafeInteger {
+			return "", fmt.Errorf("integer %d cannot be represented exactly by a TypeScript number", v)
+		}
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		return formatFloat(v, t.primitive.BitSize)
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		return "", fmt.Errorf("internal error: unsupported value %#v", v)
+	}
+}
+
+func formatFloat(f float64, bitSize int) (string, error) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return "", fmt.Errorf("float %v cannot be written as a TypeScript literal", f)
+	}
+	return strconv.FormatFloat(f, 'g', -1, bitSize), nil
+}
+
+// quote renders s as a TypeScript string literal.
+func quote(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return string(bytes.TrimRight(buf.Bytes(), "\n"))
+}
@@ -0,0 +1,5 @@
+package typescript
+
+const (
+	Version = "v0.0.1"
+)
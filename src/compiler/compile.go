@@ -13,6 +13,11 @@ import (
 
 var compilationRules = []compiler.Rule{
 	rules.NewTypeCompatibilityRule(),
+	rules.NewFlagsRule(),
+	rules.NewAnnotationRule(),
+	rules.NewDefaultMemberRule(),
+	rules.NewProtoNumberingRule(),
+	rules.NewGoEnumStyleRule(),
 }
 
 // CompileFile compiles an enum definition file and generates code for the target language
@@ -43,6 +48,7 @@ func CompileFile(filePath string, outputDir string, targetLang string, strict bo
 	pipeline.AddStage(stages.NewParseStage()).
 		AddStage(stages.NewSymbolCollector()).
 		AddStage(stages.NewTypeResolver()).
+		AddStage(stages.NewReferenceResolver()).
 		AddStage(stages.NewValidator(compilationRules)).
 		AddStage(stages.NewIRGenerator()).
 		AddStage(codegen.NewCodeGenerationStage())
@@ -0,0 +1,56 @@
+package ir
+
+import (
+	"github.com/kkumar-gcc/enumgen/src/ast"
+	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
+	"github.com/kkumar-gcc/enumgen/src/token"
+)
+
+type Annotation struct {
+	name         string
+	args         []string
+	position     token.Position
+	originalNode *ast.Annotation
+}
+
+var _ compiler.IRAnnotation = (*Annotation)(nil)
+
+func NewAnnotation(name string, args []string, position token.Position, originalNode *ast.Annotation) *Annotation {
+	return &Annotation{
+		name:         name,
+		args:         args,
+		position:     position,
+		originalNode: originalNode,
+	}
+}
+
+func (r *Annotation) Name() string {
+	return r.name
+}
+
+// Args returns the unquoted string arguments of the annotation.
+func (r *Annotation) Args() []string {
+	return r.args
+}
+
+func (r *Annotation) Position() token.Position {
+	return r.position
+}
+
+func (r *Annotation) OriginalNode() *ast.Annotation {
+	return r.originalNode
+}
+
+func (r *Annotation) String() string {
+	return r.originalNode.String()
+}
+
+// FindAnnotation returns the first annotation with the given name, or nil.
+func FindAnnotation(annotations []compiler.IRAnnotation, name string) compiler.IRAnnotation {
+	for _, a := range annotations {
+		if a.Name() == name {
+			return a
+		}
+	}
+	return nil
+}
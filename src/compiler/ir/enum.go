@@ -9,20 +9,28 @@ import (
 type EnumDefinition struct {
 	name         string
 	doc          string
+	annotations  []compiler.IRAnnotation
 	members      []compiler.IREnumMember
+	aliases      []compiler.IREnumMember
+	fields       []compiler.IRField
 	valueType    compiler.Type
 	keyType      compiler.Type
+	flags        bool
 	position     token.Position
 	originalNode *ast.EnumDefinition
 }
 
-func NewEnumDefinition(name string, doc string, members []compiler.IREnumMember, valueType compiler.Type, keyType compiler.Type, position token.Position, originalNode *ast.EnumDefinition) *EnumDefinition {
+func NewEnumDefinition(name string, doc string, annotations []compiler.IRAnnotation, members []compiler.IREnumMember, aliases []compiler.IREnumMember, fields []compiler.IRField, valueType compiler.Type, keyType compiler.Type, flags bool, position token.Position, originalNode *ast.EnumDefinition) *EnumDefinition {
 	return &EnumDefinition{
 		name:         name,
 		doc:          doc,
+		annotations:  annotations,
 		members:      members,
+		aliases:      aliases,
+		fields:       fields,
 		valueType:    valueType,
 		keyType:      keyType,
+		flags:        flags,
 		position:     position,
 		originalNode: originalNode,
 	}
@@ -36,10 +44,25 @@ func (r *EnumDefinition) Doc() string {
 	return r.doc
 }
 
+func (r *EnumDefinition) Annotations() []compiler.IRAnnotation {
+	return r.annotations
+}
+
 func (r *EnumDefinition) Members() []compiler.IREnumMember {
 	return r.members
 }
 
+// MemberAliases returns the members that are assigned another member of the
+// enum, such as `ALIAS = PRIMARY`. They are not part of Members.
+func (r *EnumDefinition) MemberAliases() []compiler.IREnumMember {
+	return r.aliases
+}
+
+// Fields returns the named fields of a tuple enum, or nil for other enums.
+func (r *EnumDefinition) Fields() []compiler.IRField {
+	return r.fields
+}
+
 func (r *EnumDefinition) ValueType() compiler.Type {
 	return r.valueType
 }
@@ -48,6 +71,10 @@ func (r *EnumDefinition) KeyType() compiler.Type {
 	return r.keyType
 }
 
+func (r *EnumDefinition) IsFlags() bool {
+	return r.flags
+}
+
 func (r *EnumDefinition) Position() token.Position {
 	return r.position
 }
@@ -68,3 +95,13 @@ func (r *EnumDefinition) FindMember(name string) compiler.IREnumMember {
 	}
 	return nil
 }
+
+// DefaultMember returns the member marked with '@default', or nil if there is none.
+func (r *EnumDefinition) DefaultMember() compiler.IREnumMember {
+	for _, member := range r.members {
+		if member.IsDefault() {
+			return member
+		}
+	}
+	return nil
+}
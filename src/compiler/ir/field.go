@@ -0,0 +1,52 @@
+package ir
+
+import (
+	"github.com/kkumar-gcc/enumgen/src/ast"
+	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
+	"github.com/kkumar-gcc/enumgen/src/token"
+)
+
+type Field struct {
+	name         string
+	typeInfo     compiler.Type
+	unique       bool
+	position     token.Position
+	originalNode *ast.Field
+}
+
+var _ compiler.IRField = (*Field)(nil)
+
+func NewField(name string, typeInfo compiler.Type, unique bool, position token.Position, originalNode *ast.Field) *Field {
+	return &Field{
+		name:         name,
+		typeInfo:     typeInfo,
+		unique:       unique,
+		position:     position,
+		originalNode: originalNode,
+	}
+}
+
+func (r *Field) Name() string {
+	return r.name
+}
+
+func (r *Field) Type() compiler.Type {
+	return r.typeInfo
+}
+
+// IsUnique reports whether members can be looked up by the field's value.
+func (r *Field) IsUnique() bool {
+	return r.unique
+}
+
+func (r *Field) Position() token.Position {
+	return r.position
+}
+
+func (r *Field) OriginalNode() *ast.Field {
+	return r.originalNode
+}
+
+func (r *Field) String() string {
+	return r.originalNode.String()
+}
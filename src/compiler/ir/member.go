@@ -9,16 +9,22 @@ import (
 type EnumMember struct {
 	name         string
 	doc          string
+	comment      string
 	value        compiler.IRValue
+	aliases      []string
+	annotations  []compiler.IRAnnotation
 	position     token.Position
 	originalNode *ast.MemberDefinition
 }
 
-func NewEnumMember(name string, doc string, value compiler.IRValue, position token.Position, originalNode *ast.MemberDefinition) *EnumMember {
+func NewEnumMember(name string, doc string, comment string, value compiler.IRValue, aliases []string, annotations []compiler.IRAnnotation, position token.Position, originalNode *ast.MemberDefinition) *EnumMember {
 	return &EnumMember{
 		name:         name,
 		doc:          doc,
+		comment:      comment,
 		value:        value,
+		aliases:      aliases,
+		annotations:  annotations,
 		position:     position,
 		originalNode: originalNode,
 	}
@@ -32,10 +38,30 @@ func (r *EnumMember) Doc() string {
 	return r.doc
 }
 
+// Comment returns the member's trailing line comment when it also has a lead
+// doc comment; otherwise the trailing comment is already reported by Doc.
+func (r *EnumMember) Comment() string {
+	return r.comment
+}
+
 func (r *EnumMember) Value() compiler.IRValue {
 	return r.value
 }
 
+// Aliases returns the unquoted alternative keys of the member.
+func (r *EnumMember) Aliases() []string {
+	return r.aliases
+}
+
+func (r *EnumMember) Annotations() []compiler.IRAnnotation {
+	return r.annotations
+}
+
+// IsDefault reports whether the member is the enum's fallback for unknown input.
+func (r *EnumMember) IsDefault() bool {
+	return FindAnnotation(r.annotations, "default") != nil
+}
+
 func (r *EnumMember) Position() token.Position {
 	return r.position
 }
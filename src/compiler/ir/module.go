@@ -4,6 +4,7 @@ import "github.com/kkumar-gcc/enumgen/src/contracts/compiler"
 
 type Module struct {
 	name   string
+	doc    string
 	enums  []compiler.IREnumDefinition
 	source string
 }
@@ -22,6 +23,10 @@ func (r *Module) Name() string {
 	return r.name
 }
 
+func (r *Module) Doc() string {
+	return r.doc
+}
+
 func (r *Module) Enums() []compiler.IREnumDefinition {
 	return r.enums
 }
@@ -44,3 +49,8 @@ func (r *Module) SetName(name string) compiler.IRModule {
 	r.name = name
 	return r
 }
+
+func (r *Module) SetDoc(doc string) compiler.IRModule {
+	r.doc = doc
+	return r
+}
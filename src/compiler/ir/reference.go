@@ -0,0 +1,49 @@
+package ir
+
+import (
+	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
+	"github.com/kkumar-gcc/enumgen/src/token"
+)
+
+type Reference struct {
+	enum   string
+	member string
+	target compiler.IRValue
+	pos    token.Position
+}
+
+var _ compiler.IRReference = (*Reference)(nil)
+
+func NewReference(enum string, member string, target compiler.IRValue, pos token.Position) *Reference {
+	return &Reference{
+		enum:   enum,
+		member: member,
+		target: target,
+		pos:    pos,
+	}
+}
+
+func (r *Reference) Position() token.Position {
+	return r.pos
+}
+
+func (r *Reference) String() string {
+	return r.enum + "." + r.member
+}
+
+// Enum returns the name of the enum declaring the referenced member.
+func (r *Reference) Enum() string {
+	return r.enum
+}
+
+// Member returns the name of the referenced member.
+func (r *Reference) Member() string {
+	return r.member
+}
+
+// Target returns the value the reference stands for, found by following
+// references to a member assigned a value that is not a reference. It is nil
+// if that member has no explicit value.
+func (r *Reference) Target() compiler.IRValue {
+	return r.target
+}
@@ -1,6 +1,9 @@
 package ir
 
 import (
+	"math/big"
+	"strconv"
+
 	"github.com/kkumar-gcc/enumgen/src/ast"
 	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
 	"github.com/kkumar-gcc/enumgen/src/token"
@@ -20,6 +23,7 @@ func NewTransformer(ctx *compiler.Context) *Transformer {
 func (t *Transformer) VisitFile(node *ast.File) any {
 	module := NewModule(t.ctx.SourcePath, "")
 	module.SetSource(string(t.ctx.SourceCode))
+	module.SetDoc(node.Doc.Text())
 
 	var enums []compiler.IREnumDefinition
 	for _, decl := range node.Declarations {
@@ -36,10 +40,7 @@ func (t *Transformer) VisitFile(node *ast.File) any {
 }
 
 func (t *Transformer) VisitEnum(node *ast.EnumDefinition) any {
-	var doc string
-	if node.Doc != nil {
-		doc = node.Doc.String()
-	}
+	doc := node.DocText()
 
 	// Determine types for the enum
 	var keyType, valueType compiler.Type
@@ -52,23 +53,49 @@ func (t *Transformer) VisitEnum(node *ast.EnumDefinition) any {
 		}
 	}
 
-	if valueType == nil {
+	if node.TypeSpec == nil && t.ctx.Symbols != nil {
+		if symbol := t.ctx.Symbols.LookupEnum(node.Name.Name); symbol != nil && symbol.Type != nil {
+			valueType = symbol.Type.ValueType()
+		}
+	}
+
+	var fields []compiler.IRField
+	if node.IsTuple() {
+		for _, field := range node.TypeSpec.Fields {
+			fields = append(fields, t.VisitField(field).(compiler.IRField))
+		}
+	} else if valueType == nil {
 		valueType = t.resolveType("string")
 	}
 
-	var members []compiler.IREnumMember
+	var members, aliases []compiler.IREnumMember
 	for _, member := range node.Members {
-		if irMember := t.VisitMember(member); irMember != nil {
-			members = append(members, irMember.(compiler.IREnumMember))
+		irMember := t.VisitMember(member)
+		if irMember == nil {
+			continue
+		}
+		m := irMember.(*EnumMember)
+		if node.IsAlias(member) {
+			aliases = append(aliases, m)
+			continue
 		}
+		if node.IsFlags() && m.value == nil {
+			// Flag members without an explicit value take the bit at their index.
+			m.value = NewLiteral(token.INT, new(big.Int).Lsh(big.NewInt(1), uint(len(members))).String(), member.Pos(), t.resolveType("int"))
+		}
+		members = append(members, m)
 	}
 
 	enum := NewEnumDefinition(
 		node.Name.Name,
 		doc,
+		t.visitAnnotations(node.Annotations),
 		members,
+		aliases,
+		fields,
 		valueType,
 		keyType,
+		node.IsFlags(),
 		node.Pos(),
 		node,
 	)
@@ -78,9 +105,10 @@ func (t *Transformer) VisitEnum(node *ast.EnumDefinition) any {
 }
 
 func (t *Transformer) VisitMember(node *ast.MemberDefinition) any {
-	var doc string
-	if node.Doc != nil {
-		doc = node.Doc.String()
+	doc := node.DocText()
+	var comment string
+	if node.Doc.Text() != "" {
+		comment = node.Comment.Text()
 	}
 
 	var value compiler.IRValue
@@ -90,21 +118,67 @@ func (t *Transformer) VisitMember(node *ast.MemberDefinition) any {
 		}
 	}
 
+	var aliases []string
+	for _, alias := range node.Aliases {
+		if unquoted, err := strconv.Unquote(alias.Value); err == nil {
+			aliases = append(aliases, unquoted)
+		}
+	}
+
 	return NewEnumMember(
 		node.Name.Name,
 		doc,
+		comment,
 		value,
+		aliases,
+		t.visitAnnotations(node.Annotations),
 		node.Pos(),
 		node,
 	)
 }
 
+func (t *Transformer) VisitField(node *ast.Field) any {
+	return NewField(
+		node.Name.Name,
+		t.resolveType(node.Type.Name.Name),
+		node.IsUnique(),
+		node.Pos(),
+		node,
+	)
+}
+
+func (t *Transformer) VisitAnnotation(node *ast.Annotation) any {
+	var args []string
+	for _, arg := range node.Args {
+		if lit, ok := arg.(*ast.BasicLit); ok {
+			if unquoted, err := strconv.Unquote(lit.Value); err == nil {
+				args = append(args, unquoted)
+			}
+		}
+	}
+	return NewAnnotation(node.Name.Name, args, node.Pos(), node)
+}
+
+func (t *Transformer) visitAnnotations(nodes []*ast.Annotation) []compiler.IRAnnotation {
+	var annotations []compiler.IRAnnotation
+	for _, node := range nodes {
+		annotations = append(annotations, t.VisitAnnotation(node).(compiler.IRAnnotation))
+	}
+	return annotations
+}
+
 func (t *Transformer) VisitValue(node ast.Expr) any {
 	switch v := node.(type) {
 	case *ast.BasicLit:
 		return t.VisitLiteral(v)
+	case *ast.UnaryExpr:
+		return t.VisitUnary(v)
 	case *ast.KeyValueExpr:
 		return t.VisitKeyValue(v)
+	case *ast.TupleExpr:
+		return t.VisitTuple(v)
+	case *ast.RefExpr:
+		return t.VisitReference(v)
 	default:
 		return nil
 	}
@@ -133,6 +207,19 @@ func (t *Transformer) VisitLiteral(node *ast.BasicLit) any {
 	)
 }
 
+func (t *Transformer) VisitUnary(node *ast.UnaryExpr) any {
+	irOperand := t.VisitValue(node.X)
+	if irOperand == nil {
+		return nil
+	}
+
+	return NewUnary(
+		node.Op,
+		irOperand.(compiler.IRValue),
+		node.Pos(),
+	)
+}
+
 func (t *Transformer) VisitKeyValue(node *ast.KeyValueExpr) any {
 	var key, value compiler.IRValue
 
@@ -151,6 +238,42 @@ func (t *Transformer) VisitKeyValue(node *ast.KeyValueExpr) any {
 	)
 }
 
+func (t *Transformer) VisitTuple(node *ast.TupleExpr) any {
+	var elements []compiler.IRValue
+	for _, elt := range node.Elts {
+		if irValue := t.VisitValue(elt); irValue != nil {
+			elements = append(elements, irValue.(compiler.IRValue))
+		}
+	}
+
+	return NewTuple(
+		elements,
+		node.Pos(),
+	)
+}
+
+func (t *Transformer) VisitReference(node *ast.RefExpr) any {
+	if node.Target == nil || node.TargetEnum == nil {
+		return nil
+	}
+
+	// Only literal values are followed; composite ones may refer back to this member.
+	var target compiler.IRValue
+	if final := node.Final(); final != nil {
+		switch final.Value.(type) {
+		case *ast.BasicLit, *ast.UnaryExpr:
+			target = t.VisitValue(final.Value).(compiler.IRValue)
+		}
+	}
+
+	return NewReference(
+		node.TargetEnum.Name.Name,
+		node.Target.Name.Name,
+		target,
+		node.Pos(),
+	)
+}
+
 func (t *Transformer) resolveType(name string) compiler.Type {
 	if t.ctx.Types != nil {
 		return t.ctx.Types.LookupType(name)
@@ -0,0 +1,39 @@
+package ir
+
+import (
+	"strings"
+
+	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
+	"github.com/kkumar-gcc/enumgen/src/token"
+)
+
+type Tuple struct {
+	elements []compiler.IRValue
+	pos      token.Position
+}
+
+var _ compiler.IRTuple = (*Tuple)(nil)
+
+func NewTuple(elements []compiler.IRValue, pos token.Position) *Tuple {
+	return &Tuple{
+		elements: elements,
+		pos:      pos,
+	}
+}
+
+func (r *Tuple) Position() token.Position {
+	return r.pos
+}
+
+func (r *Tuple) String() string {
+	parts := make([]string, len(r.elements))
+	for i, e := range r.elements {
+		parts[i] = e.String()
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+// Elements returns the values of the tuple in field order.
+func (r *Tuple) Elements() []compiler.IRValue {
+	return r.elements
+}
@@ -0,0 +1,38 @@
+package ir
+
+import (
+	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
+	"github.com/kkumar-gcc/enumgen/src/token"
+)
+
+type Unary struct {
+	op      token.Token
+	operand compiler.IRValue
+	pos     token.Position
+}
+
+var _ compiler.IRUnary = (*Unary)(nil)
+
+func NewUnary(op token.Token, operand compiler.IRValue, pos token.Position) *Unary {
+	return &Unary{
+		op:      op,
+		operand: operand,
+		pos:     pos,
+	}
+}
+
+func (r *Unary) Position() token.Position {
+	return r.pos
+}
+
+func (r *Unary) String() string {
+	return r.op.String() + r.operand.String()
+}
+
+func (r *Unary) Operator() token.Token {
+	return r.op
+}
+
+func (r *Unary) Operand() compiler.IRValue {
+	return r.operand
+}
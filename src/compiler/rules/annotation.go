@@ -0,0 +1,129 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/kkumar-gcc/enumgen/src/ast"
+	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
+	"github.com/kkumar-gcc/enumgen/src/errors"
+	"github.com/kkumar-gcc/enumgen/src/token"
+)
+
+// annotationSpec describes where an annotation may appear and which arguments it takes.
+type annotationSpec struct {
+	onEnum   bool
+	onMember bool
+	onFlags  bool // whether the annotation is allowed on members of flags enums
+	onAlias  bool // whether the annotation is allowed on members aliasing another member
+	minArgs  int
+	maxArgs  int
+	usage    string
+}
+
+var annotationSpecs = map[string]annotationSpec{
+	"default":    {onMember: true, usage: "@default"},
+	"deprecated": {onEnum: true, onMember: true, onFlags: true, onAlias: true, maxArgs: 1, usage: `@deprecated or @deprecated("use OTHER instead")`},
+	"label":      {onMember: true, minArgs: 1, maxArgs: 1, usage: `@label("Human readable label")`},
+	"json":       {onMember: true, minArgs: 1, maxArgs: 1, usage: `@json("json_name")`},
+}
+
+// AnnotationRule validates the annotations of enums and members: each must be
+// known, allowed where it appears, given at most once and called with string
+// arguments matching its signature.
+type AnnotationRule struct{}
+
+func NewAnnotationRule() *AnnotationRule {
+	return &AnnotationRule{}
+}
+
+func (r *AnnotationRule) Name() string {
+	return "AnnotationRule"
+}
+
+func (r *AnnotationRule) Check(ctx *compiler.Context, node ast.Node) []compiler.Issue {
+	enumDef, ok := node.(*ast.EnumDefinition)
+	if !ok {
+		return nil
+	}
+
+	issues := r.checkAnnotations(enumDef.Annotations, "enum "+enumDef.Name.Name, func(spec annotationSpec) bool {
+		return spec.onEnum
+	})
+
+	for _, member := range enumDef.Members {
+		target := fmt.Sprintf("member %s of enum %s", member.Name.Name, enumDef.Name.Name)
+		issues = append(issues, r.checkAnnotations(member.Annotations, target, func(spec annotationSpec) bool {
+			return spec.onMember && (spec.onFlags || !enumDef.IsFlags()) && (spec.onAlias || !enumDef.IsAlias(member))
+		})...)
+	}
+
+	return issues
+}
+
+func (r *AnnotationRule) checkAnnotations(annotations []*ast.Annotation, target string, allowed func(annotationSpec) bool) []compiler.Issue {
+	var issues []compiler.Issue
+	seen := make(map[string]bool)
+
+	for _, a := range annotations {
+		name := a.Name.Name
+		spec, known := annotationSpecs[name]
+		switch {
+		case !known:
+			issues = append(issues, r.newError(a.Pos(),
+				fmt.Sprintf("unknown annotation @%s on %s", name, target),
+				"use one of @default, @deprecated, @label or @json"))
+			continue
+		case !allowed(spec):
+			issues = append(issues, r.newError(a.Pos(),
+				fmt.Sprintf("annotation @%s is not allowed on %s", name, target),
+				"remove the annotation"))
+			continue
+		case seen[name]:
+			issues = append(issues, r.newError(a.Pos(),
+				fmt.Sprintf("annotation @%s is given more than once on %s", name, target),
+				"remove the duplicate annotation"))
+			continue
+		}
+		seen[name] = true
+
+		if len(a.Args) < spec.minArgs || len(a.Args) > spec.maxArgs {
+			issues = append(issues, r.newError(a.Pos(),
+				fmt.Sprintf("annotation @%s on %s takes %s, got %d", name, target, argCount(spec), len(a.Args)),
+				"use "+spec.usage))
+			continue
+		}
+
+		for _, arg := range a.Args {
+			if lit, ok := arg.(*ast.BasicLit); !ok || lit.Kind != token.STRING {
+				issues = append(issues, r.newError(arg.Pos(),
+					fmt.Sprintf("argument %s of annotation @%s must be a string literal", arg.String(), name),
+					"use "+spec.usage))
+			}
+		}
+	}
+
+	return issues
+}
+
+func (r *AnnotationRule) newError(pos token.Position, msg, fix string) compiler.Issue {
+	return compiler.Issue{
+		Position: pos,
+		Message:  msg,
+		Fix:      fix,
+		RuleName: r.Name(),
+		Severity: errors.SeverityError,
+	}
+}
+
+func argCount(spec annotationSpec) string {
+	switch {
+	case spec.maxArgs == 0:
+		return "no arguments"
+	case spec.maxArgs == 1 && spec.minArgs == 1:
+		return "exactly one argument"
+	case spec.maxArgs == 1:
+		return "at most one argument"
+	default:
+		return fmt.Sprintf("%d to %d arguments", spec.minArgs, spec.maxArgs)
+	}
+}
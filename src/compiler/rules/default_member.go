@@ -0,0 +1,66 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/kkumar-gcc/enumgen/src/ast"
+	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
+	"github.com/kkumar-gcc/enumgen/src/errors"
+	"github.com/kkumar-gcc/enumgen/src/token"
+)
+
+// DefaultMemberRule validates members marked with '@default'. An enum may
+// declare at most one fallback member, and flags enums may declare none,
+// since an unknown flag set has no single member to fall back to.
+type DefaultMemberRule struct{}
+
+func NewDefaultMemberRule() *DefaultMemberRule {
+	return &DefaultMemberRule{}
+}
+
+func (r *DefaultMemberRule) Name() string {
+	return "DefaultMemberRule"
+}
+
+func (r *DefaultMemberRule) Check(ctx *compiler.Context, node ast.Node) []compiler.Issue {
+	enumDef, ok := node.(*ast.EnumDefinition)
+	if !ok {
+		return nil
+	}
+
+	var issues []compiler.Issue
+	var first *ast.MemberDefinition
+	for _, member := range enumDef.Members {
+		def := member.Annotation("default")
+		if def == nil {
+			continue
+		}
+
+		if enumDef.IsFlags() {
+			issues = append(issues, r.newError(def.Pos(),
+				fmt.Sprintf("flags enum %s cannot declare a default member", enumDef.Name.Name),
+				"remove '@default' from "+member.Name.Name))
+			continue
+		}
+
+		if first != nil {
+			issues = append(issues, r.newError(def.Pos(),
+				fmt.Sprintf("member %s of enum %s is marked '@default', but %s already is", member.Name.Name, enumDef.Name.Name, first.Name.Name),
+				"mark a single member of the enum with '@default'"))
+			continue
+		}
+		first = member
+	}
+
+	return issues
+}
+
+func (r *DefaultMemberRule) newError(pos token.Position, msg, fix string) compiler.Issue {
+	return compiler.Issue{
+		Position: pos,
+		Message:  msg,
+		Fix:      fix,
+		RuleName: r.Name(),
+		Severity: errors.SeverityError,
+	}
+}
@@ -0,0 +1,142 @@
+package rules
+
+import (
+	"fmt"
+	goconst "go/constant"
+	gotoken "go/token"
+	"math/big"
+
+	"github.com/kkumar-gcc/enumgen/src/ast"
+	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
+	"github.com/kkumar-gcc/enumgen/src/errors"
+	"github.com/kkumar-gcc/enumgen/src/token"
+)
+
+// FlagsRule validates enums declared with the 'flags' modifier. Every member
+// must be a single bit, either given explicitly or assigned implicitly as
+// 1 << index, and must fit within the declared integer width.
+type FlagsRule struct{}
+
+func NewFlagsRule() *FlagsRule {
+	return &FlagsRule{}
+}
+
+func (r *FlagsRule) Name() string {
+	return "FlagsRule"
+}
+
+func (r *FlagsRule) Check(ctx *compiler.Context, node ast.Node) []compiler.Issue {
+	enumDef, ok := node.(*ast.EnumDefinition)
+	if !ok || !enumDef.IsFlags() {
+		return nil
+	}
+
+	if enumDef.TypeSpec == nil || len(enumDef.TypeSpec.Types) != 1 || !isIntegerType(enumDef.TypeSpec.Types[0].Name.Name) {
+		return []compiler.Issue{r.newError(enumDef.FlagsPos,
+			fmt.Sprintf("flags enum %s must declare exactly one integer type", enumDef.Name.Name),
+			"declare an unsigned integer type such as [uint8] or [uint32]")}
+	}
+	declared := enumDef.TypeSpec.Types[0].Name.Name
+
+	type flagValue struct {
+		pos      token.Position
+		explicit bool
+	}
+	seen := make(map[string]flagValue)
+
+	var issues []compiler.Issue
+	i := -1 // index of the member among those that are not aliases
+	for _, member := range enumDef.Members {
+		if enumDef.IsAlias(member) {
+			continue
+		}
+		i++
+
+		var lit *ast.BasicLit
+		explicit := member.Value != nil
+
+		switch expr := member.Value.(type) {
+		case nil:
+			lit = &ast.BasicLit{
+				ValuePos: member.Pos(),
+				Kind:     token.INT,
+				Value:    new(big.Int).Lsh(big.NewInt(1), uint(i)).String(),
+			}
+			if err := isFitsInTypeRange(lit, declared); err != nil {
+				issues = append(issues, r.newError(member.Pos(),
+					fmt.Sprintf("implicit flag value %s of member %s overflows %s", lit.Value, member.Name.Name, declared),
+					"use a wider integer type or fewer members"))
+				continue
+			}
+		case *ast.BasicLit:
+			if expr.Kind != token.INT {
+				issues = append(issues, r.newError(expr.Pos(),
+					fmt.Sprintf("flag value %s of member %s must be an integer literal", expr.Value, member.Name.Name),
+					"use a power-of-two integer literal such as 1, 2, 4 or 0x8"))
+				continue
+			}
+			if !isSingleBit(expr) {
+				issues = append(issues, r.newError(expr.Pos(),
+					fmt.Sprintf("flag value %s of member %s is not a single bit", expr.Value, member.Name.Name),
+					"use a power-of-two value, or omit the value to take the bit at the member's position (1 << position)"))
+				continue
+			}
+			lit = expr
+		case *ast.UnaryExpr:
+			issues = append(issues, r.newError(expr.Pos(),
+				fmt.Sprintf("flag value %s of member %s is not a single bit", expr.String(), member.Name.Name),
+				"use a positive power-of-two value"))
+			continue
+		default:
+			issues = append(issues, r.newError(member.Pos(),
+				fmt.Sprintf("flag member %s must have a single integer value", member.Name.Name),
+				"use a power-of-two integer literal or omit the value"))
+			continue
+		}
+
+		val, err := makeUntypedConst(lit)
+		if err != nil {
+			continue
+		}
+		key := val.ExactString()
+		if prev, exists := seen[key]; exists {
+			// Collisions between two explicit values are reported by TypeCompatibilityRule.
+			if explicit && prev.explicit {
+				continue
+			}
+			issues = append(issues, r.newError(member.Pos(),
+				fmt.Sprintf("flag value %s of member %s collides with the member at %v", key, member.Name.Name, prev.pos),
+				"give each member a distinct bit"))
+			continue
+		}
+		seen[key] = flagValue{pos: member.Pos(), explicit: explicit}
+	}
+
+	return issues
+}
+
+func (r *FlagsRule) newError(pos token.Position, msg, fix string) compiler.Issue {
+	return compiler.Issue{
+		Position: pos,
+		Message:  msg,
+		Fix:      fix,
+		RuleName: r.Name(),
+		Severity: errors.SeverityError,
+	}
+}
+
+// isSingleBit reports whether lit is a positive integer with exactly one bit set.
+func isSingleBit(lit *ast.BasicLit) bool {
+	val, err := makeUntypedConst(lit)
+	if err != nil || val.Kind() != goconst.Int || goconst.Sign(val) <= 0 {
+		return false
+	}
+	prev := goconst.BinaryOp(val, gotoken.SUB, goconst.MakeInt64(1))
+	return goconst.Sign(goconst.BinaryOp(val, gotoken.AND, prev)) == 0
+}
+
+func isIntegerType(name string) bool {
+	_, signed := intTypeToBitSize[name]
+	_, unsigned := uintTypeToBitSize[name]
+	return signed || unsigned
+}
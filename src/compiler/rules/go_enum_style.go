@@ -0,0 +1,62 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/kkumar-gcc/enumgen/src/ast"
+	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
+	"github.com/kkumar-gcc/enumgen/src/errors"
+	"github.com/kkumar-gcc/enumgen/src/token"
+)
+
+const (
+	// goTarget is the language of the Go generator.
+	goTarget = "go"
+
+	// goEnumStyle is the Go generator option that selects how enums are declared.
+	goEnumStyle = "enum_style"
+
+	// goStandardStyle is the only Go enum style whose struct members can hold
+	// the fields of a tuple enum.
+	goStandardStyle = "standard"
+)
+
+// GoEnumStyleRule reports tuple enums when the Go generator is asked for a
+// style other than the standard one. Tuple members carry several values, so
+// the generator declares them as structs whatever style was requested.
+type GoEnumStyleRule struct{}
+
+func NewGoEnumStyleRule() *GoEnumStyleRule {
+	return &GoEnumStyleRule{}
+}
+
+func (r *GoEnumStyleRule) Name() string {
+	return "GoEnumStyleRule"
+}
+
+func (r *GoEnumStyleRule) Check(ctx *compiler.Context, node ast.Node) []compiler.Issue {
+	enumDef, ok := node.(*ast.EnumDefinition)
+	if !ok || ctx.TargetLang != goTarget || !enumDef.IsTuple() {
+		return nil
+	}
+
+	// An unknown style is reported by the generator.
+	style := ctx.GenerationConfig[goEnumStyle]
+	if style == "" || style == goStandardStyle {
+		return nil
+	}
+
+	return []compiler.Issue{r.newWarning(enumDef.Name.Pos(),
+		fmt.Sprintf("tuple enum %s cannot use enum style '%s' and is generated in the '%s' style", enumDef.Name.Name, style, goStandardStyle),
+		fmt.Sprintf("set -O %s=%s, or give %s a single value type", goEnumStyle, goStandardStyle, enumDef.Name.Name))}
+}
+
+func (r *GoEnumStyleRule) newWarning(pos token.Position, msg, fix string) compiler.Issue {
+	return compiler.Issue{
+		Position: pos,
+		Message:  msg,
+		Fix:      fix,
+		RuleName: r.Name(),
+		Severity: errors.SeverityWarning,
+	}
+}
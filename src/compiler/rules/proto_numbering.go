@@ -0,0 +1,147 @@
+package rules
+
+import (
+	"fmt"
+	goconst "go/constant"
+	gotoken "go/token"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/kkumar-gcc/enumgen/pkg/strcase"
+	"github.com/kkumar-gcc/enumgen/src/ast"
+	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
+	"github.com/kkumar-gcc/enumgen/src/errors"
+	"github.com/kkumar-gcc/enumgen/src/token"
+)
+
+const (
+	// protoTarget is the language of the proto generator.
+	protoTarget = "proto"
+
+	// protoAllowAlias is the proto generator option that lets members share
+	// numbers by generating 'option allow_alias = true'.
+	protoAllowAlias = "allow_alias"
+)
+
+// ProtoNumberingRule validates the numbers enums keep in protobuf, which the
+// proto generator takes from their first integer type. It only applies when
+// generating proto, and reports negative numbers, numbers beyond int32 and
+// numbers shared by two members unless aliases are allowed. Since every
+// generated enum starts with <ENUM>_UNSPECIFIED = 0, members numbered 0 are
+// shared numbers as well.
+type ProtoNumberingRule struct{}
+
+func NewProtoNumberingRule() *ProtoNumberingRule {
+	return &ProtoNumberingRule{}
+}
+
+func (r *ProtoNumberingRule) Name() string {
+	return "ProtoNumberingRule"
+}
+
+func (r *ProtoNumberingRule) Check(ctx *compiler.Context, node ast.Node) []compiler.Issue {
+	enumDef, ok := node.(*ast.EnumDefinition)
+	if !ok || ctx.TargetLang != protoTarget || enumDef.IsTuple() {
+		return nil
+	}
+
+	// Enums without an integer type are numbered in declaration order.
+	numbered := -1
+	for i, name := range declaredTypes(ctx, enumDef) {
+		if isIntegerType(name) {
+			numbered = i
+			break
+		}
+	}
+	if numbered < 0 {
+		return nil
+	}
+
+	// An invalid option is reported by the generator.
+	allowAlias, _ := strconv.ParseBool(ctx.GenerationConfig[protoAllowAlias])
+	unspecified := strings.ToUpper(strcase.ToSnake(enumDef.Name.Name)) + "_UNSPECIFIED"
+	owners := map[string]string{"0": unspecified}
+
+	var issues []compiler.Issue
+	i := -1 // index of the member among those that are not aliases
+	for _, member := range enumDef.Members {
+		if enumDef.IsAlias(member) {
+			continue
+		}
+		i++
+
+		var val goconst.Value
+		pos := member.Pos()
+		switch expr := member.Value.(type) {
+		case nil:
+			val = goconst.MakeInt64(int64(i))
+			if enumDef.IsFlags() {
+				val = goconst.Shift(goconst.MakeInt64(1), gotoken.SHL, uint(i))
+			}
+		case *ast.KeyValueExpr:
+			if numbered == 0 {
+				val, pos = r.number(expr.Key)
+			} else {
+				val, pos = r.number(expr.Value)
+			}
+		default:
+			val, pos = r.number(expr)
+		}
+		if val == nil {
+			// Values that are not integers are reported by TypeCompatibilityRule.
+			continue
+		}
+
+		number := val.ExactString()
+		switch {
+		case goconst.Sign(val) < 0:
+			issues = append(issues, r.newError(pos,
+				fmt.Sprintf("number %s of member %s is negative, which protobuf encodes in ten bytes", number, member.Name.Name),
+				"use a non-negative number"))
+		case goconst.Compare(val, gotoken.GTR, goconst.MakeInt64(math.MaxInt32)):
+			issues = append(issues, r.newError(pos,
+				fmt.Sprintf("number %s of member %s does not fit in a protobuf enum, which holds int32 numbers", number, member.Name.Name),
+				fmt.Sprintf("use a number of at most %d", math.MaxInt32)))
+		default:
+			owner, taken := owners[number]
+			if !taken {
+				owners[number] = member.Name.Name
+				continue
+			}
+			if allowAlias {
+				continue
+			}
+			fix := "give each member a distinct number, or set -O allow_alias=true to generate aliases"
+			if number == "0" {
+				fix = "number the members from 1, or set -O allow_alias=true to make the member an alias of " + unspecified
+			}
+			issues = append(issues, r.newError(pos,
+				fmt.Sprintf("number %s of member %s is already held by %s", number, member.Name.Name, owner),
+				fix))
+		}
+	}
+
+	return issues
+}
+
+// number returns the integer expr stands for and its position, or nil if it
+// is not an integer.
+func (r *ProtoNumberingRule) number(expr ast.Expr) (goconst.Value, token.Position) {
+	pos := expr.Pos()
+	val, err := makeUntypedConst(referencedValue(expr))
+	if err != nil || val.Kind() != goconst.Int {
+		return nil, pos
+	}
+	return val, pos
+}
+
+func (r *ProtoNumberingRule) newError(pos token.Position, msg, fix string) compiler.Issue {
+	return compiler.Issue{
+		Position: pos,
+		Message:  msg,
+		Fix:      fix,
+		RuleName: r.Name(),
+		Severity: errors.SeverityError,
+	}
+}
@@ -29,16 +29,41 @@ func (r *TypeCompatibilityRule) Check(ctx *compiler.Context, node ast.Node) []co
 		return nil
 	}
 
-	declared := r.declaredTypes(enumDef)
+	if enumDef.IsTuple() {
+		return r.checkTupleEnum(ctx, enumDef)
+	}
+
+	declared := declaredTypes(ctx, enumDef)
 	used := make(map[string]struct{})
 	var issues []compiler.Issue
 
 	for _, member := range enumDef.Members {
+		if len(member.Aliases) > 0 && !enumDef.IsFlags() && len(declared) > 0 && declared[0] != "string" {
+			issues = append(issues, r.newError(member.AliasPos,
+				fmt.Sprintf("aliases of member %s require a string key, but enum %s is keyed by %s", member.Name.Name, enumDef.Name.Name, declared[0]),
+				"remove the aliases or declare string as the enum's first type"))
+		}
+		if a := member.Annotation("json"); a != nil && !enumDef.IsFlags() && len(declared) > 0 && declared[0] != "string" {
+			issues = append(issues, r.newError(a.Pos(),
+				fmt.Sprintf("@json name of member %s requires a string key, but enum %s is keyed by %s", member.Name.Name, enumDef.Name.Name, declared[0]),
+				"remove the annotation or declare string as the enum's first type"))
+		}
+
 		switch expr := member.Value.(type) {
 		case *ast.KeyValueExpr:
 			issues = append(issues, r.checkKeyValue(expr, declared, used)...)
-		case *ast.BasicLit:
+		case *ast.BasicLit, *ast.UnaryExpr:
 			issues = append(issues, r.checkLiteralMember(expr, declared, used)...)
+		case *ast.RefExpr:
+			if enumDef.IsAlias(member) {
+				issues = append(issues, r.checkAliasMember(enumDef, member)...)
+			} else {
+				issues = append(issues, r.checkLiteralMember(expr, declared, used)...)
+			}
+		case *ast.TupleExpr:
+			issues = append(issues, r.newError(expr.Pos(),
+				fmt.Sprintf("member %s has a tuple value, but enum %s does not declare named fields", member.Name.Name, enumDef.Name.Name),
+				"declare fields such as [code int, text string] or assign a single literal"))
 		case nil:
 			// This case handles iota-style enum members (e.g., "NORTH,") that have no
 			// explicit value assigned in the source file. From a type-checking
@@ -55,9 +80,19 @@ func (r *TypeCompatibilityRule) Check(ctx *compiler.Context, node ast.Node) []co
 	return issues
 }
 
-func (r *TypeCompatibilityRule) declaredTypes(def *ast.EnumDefinition) []string {
+// declaredTypes returns the names of the types of def, which for enums
+// without a type specification is the type the resolver chose.
+func declaredTypes(ctx *compiler.Context, def *ast.EnumDefinition) []string {
 	if def.TypeSpec == nil {
-		return nil
+		// Enums without a type specification take the type chosen by the resolver.
+		if ctx.Symbols == nil {
+			return nil
+		}
+		symbol := ctx.Symbols.LookupEnum(def.Name.Name)
+		if symbol == nil || symbol.Type == nil || symbol.Type.ValueType() == nil {
+			return nil
+		}
+		return []string{symbol.Type.ValueType().Name()}
 	}
 	names := make([]string, len(def.TypeSpec.Types))
 	for i, t := range def.TypeSpec.Types {
@@ -78,19 +113,129 @@ func (r *TypeCompatibilityRule) checkKeyValue(expr *ast.KeyValueExpr, declared [
 	issues = append(issues, r.checkLiteral(expr.Key, declared[0], expr.Key.Pos(), fmt.Sprintf("key literal must be type %s", declared[0]), fmt.Sprintf("use literal type %s", declared[0]))...)
 	issues = append(issues, r.checkLiteral(expr.Value, declared[1], expr.Value.Pos(), fmt.Sprintf("value literal must be type %s", declared[1]), fmt.Sprintf("use literal type %s", declared[1]))...)
 
-	if lit, ok := expr.Key.(*ast.BasicLit); ok {
-		if _, seen := used[lit.Value]; seen {
-			issues = append(issues, r.newError(lit.Pos(),
+	switch key := referencedValue(expr.Key).(type) {
+	case *ast.BasicLit, *ast.UnaryExpr:
+		if _, seen := used[key.String()]; seen {
+			issues = append(issues, r.newError(key.Pos(),
 				"duplicate enum key literal",
 				"ensure each key literal is unique"))
 		} else {
-			used[lit.Value] = struct{}{}
+			used[key.String()] = struct{}{}
 		}
 	}
 	return issues
 }
 
-func (r *TypeCompatibilityRule) checkLiteralMember(lit *ast.BasicLit, declared []string, used map[string]struct{}) []compiler.Issue {
+// checkTupleEnum checks that every member of a tuple enum is assigned one
+// literal per declared field, that each literal matches its field's type and
+// that fields marked 'unique' hold distinct values.
+func (r *TypeCompatibilityRule) checkTupleEnum(ctx *compiler.Context, enumDef *ast.EnumDefinition) []compiler.Issue {
+	fields := enumDef.TypeSpec.Fields
+	var issues []compiler.Issue
+
+	names := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		if _, seen := names[field.Name.Name]; seen {
+			issues = append(issues, r.newError(field.Pos(),
+				fmt.Sprintf("duplicate field %s in enum %s", field.Name.Name, enumDef.Name.Name),
+				"give each field a unique name"))
+		}
+		names[field.Name.Name] = struct{}{}
+	}
+
+	used := make([]map[string]string, len(fields))
+	for i := range used {
+		used[i] = make(map[string]string)
+	}
+
+	for _, member := range enumDef.Members {
+		if enumDef.IsAlias(member) {
+			issues = append(issues, r.checkAliasMember(enumDef, member)...)
+			continue
+		}
+
+		tuple, ok := member.Value.(*ast.TupleExpr)
+		if !ok {
+			pos := member.Pos()
+			if member.Value != nil {
+				pos = member.Value.Pos()
+			}
+			issues = append(issues, r.newError(pos,
+				fmt.Sprintf("member %s of enum %s must be assigned a tuple of %d values", member.Name.Name, enumDef.Name.Name, len(fields)),
+				"assign one value per field, e.g. (200, \"OK\")"))
+			continue
+		}
+
+		if len(tuple.Elts) != len(fields) {
+			issues = append(issues, r.newError(tuple.Pos(),
+				fmt.Sprintf("member %s has %d values, but enum %s declares %d fields", member.Name.Name, len(tuple.Elts), enumDef.Name.Name, len(fields)),
+				"assign one value per field"))
+			continue
+		}
+
+		for i, elt := range tuple.Elts {
+			field := fields[i]
+			typeName := field.Type.Name.Name
+
+			var key string
+			if ctx.Symbols != nil && ctx.Symbols.LookupEnum(typeName) != nil {
+				// Fields of an enum type hold references to its members.
+				ref, isRef := elt.(*ast.RefExpr)
+				if !isRef || (ref.TargetEnum != nil && ref.TargetEnum.Name.Name != typeName) {
+					issues = append(issues, r.newError(elt.Pos(),
+						fmt.Sprintf("value %s of field %s must be a member of %s", elt.String(), field.Name.Name, typeName),
+						fmt.Sprintf("reference a member such as %s.MEMBER", typeName)))
+					continue
+				}
+				final := ref.Final()
+				if final == nil {
+					continue
+				}
+				key = final.Name.Name
+			} else {
+				fieldIssues := r.checkLiteral(elt, typeName, elt.Pos(),
+					fmt.Sprintf("value of field %s must be type %s", field.Name.Name, typeName),
+					fmt.Sprintf("use literal type %s", typeName))
+				issues = append(issues, fieldIssues...)
+				if len(fieldIssues) > 0 || !field.IsUnique() {
+					continue
+				}
+				val, err := makeUntypedConst(referencedValue(elt))
+				if err != nil {
+					continue
+				}
+				key = val.ExactString()
+			}
+			if !field.IsUnique() {
+				continue
+			}
+
+			if owner, seen := used[i][key]; seen {
+				issues = append(issues, r.newError(elt.Pos(),
+					fmt.Sprintf("value %s of unique field %s is already used by member %s", elt.String(), field.Name.Name, owner),
+					"give each member a distinct value for this field"))
+				continue
+			}
+			used[i][key] = member.Name.Name
+		}
+	}
+
+	return issues
+}
+
+// checkAliasMember checks a member that is assigned another member of its
+// enum. Such a member is another name for that member rather than a member of
+// its own, so it cannot declare keys of its own.
+func (r *TypeCompatibilityRule) checkAliasMember(enumDef *ast.EnumDefinition, member *ast.MemberDefinition) []compiler.Issue {
+	if len(member.Aliases) == 0 {
+		return nil
+	}
+	return []compiler.Issue{r.newError(member.AliasPos,
+		fmt.Sprintf("member %s of enum %s is an alias of %s and cannot declare aliases", member.Name.Name, enumDef.Name.Name, member.Value.String()),
+		"declare the aliases on "+member.Value.String())}
+}
+
+func (r *TypeCompatibilityRule) checkLiteralMember(lit ast.Expr, declared []string, used map[string]struct{}) []compiler.Issue {
 	pos := lit.Pos()
 	if len(declared) != 1 {
 		return []compiler.Issue{r.newError(pos,
@@ -101,24 +246,46 @@ func (r *TypeCompatibilityRule) checkLiteralMember(lit *ast.BasicLit, declared [
 
 	issues = append(issues, r.checkLiteral(lit, declared[0], pos, fmt.Sprintf("literal must be type %s", declared[0]), fmt.Sprintf("use literal type %s", declared[0]))...)
 
-	if _, seen := used[lit.Value]; seen {
+	key := referencedValue(lit).String()
+	if _, seen := used[key]; seen {
 		issues = append(issues, r.newError(pos,
 			"duplicate enum literal",
 			"ensure each literal is unique"))
 	} else {
-		used[lit.Value] = struct{}{}
+		used[key] = struct{}{}
 	}
 	return issues
 }
 
 func (r *TypeCompatibilityRule) checkLiteral(expr ast.Expr, expectedType string, exprPos token.Position, msg, fix string) []compiler.Issue {
-	lit, ok := expr.(*ast.BasicLit)
-	if !ok {
+	if ref, ok := expr.(*ast.RefExpr); ok {
+		// A reference stands for the value of the member it names.
+		final := ref.Final()
+		switch {
+		case final == nil:
+			// Unresolved references and cycles are reported by the resolver.
+			return nil
+		case final.Value == nil:
+			return []compiler.Issue{r.newError(exprPos,
+				fmt.Sprintf("member %s referenced by %s has no explicit value", final.Name.Name, ref.String()),
+				"reference a member that is assigned a literal")}
+		}
+		expr = final.Value
+	}
+
+	switch expr.(type) {
+	case *ast.BasicLit, *ast.UnaryExpr:
+	default:
 		return []compiler.Issue{r.newError(exprPos, msg, fix)}
 	}
-	if err := isFitsInTypeRange(lit, expectedType); err != nil {
+	if err := isFitsInTypeRange(expr, expectedType); err != nil {
+		if _, isUnary := expr.(*ast.UnaryExpr); isUnary {
+			if _, isUnsigned := uintTypeToBitSize[expectedType]; isUnsigned {
+				fix = fmt.Sprintf("use a non-negative value or a signed type such as %s", expectedType[1:])
+			}
+		}
 		return []compiler.Issue{r.newError(exprPos,
-			fmt.Sprintf("literal %s is not a valid %s: %v", lit.Value, expectedType, err),
+			fmt.Sprintf("literal %s is not a valid %s: %v", expr.String(), expectedType, err),
 			fix)}
 	}
 	return nil
@@ -134,7 +301,35 @@ func (r *TypeCompatibilityRule) newError(pos token.Position, msg, fix string) co
 	}
 }
 
-func makeUntypedConst(lit *ast.BasicLit) (goconst.Value, error) {
+// referencedValue returns the value a member reference stands for, or expr
+// itself if it is not a resolved reference.
+func referencedValue(expr ast.Expr) ast.Expr {
+	if ref, ok := expr.(*ast.RefExpr); ok {
+		if final := ref.Final(); final != nil && final.Value != nil {
+			return final.Value
+		}
+	}
+	return expr
+}
+
+func makeUntypedConst(expr ast.Expr) (goconst.Value, error) {
+	if unary, ok := expr.(*ast.UnaryExpr); ok {
+		operand, isLit := unary.X.(*ast.BasicLit)
+		if unary.Op != token.SUB || !isLit || (operand.Kind != token.INT && operand.Kind != token.FLOAT) {
+			return nil, fmt.Errorf("unary %s requires a numeric operand", unary.Op)
+		}
+		val, err := makeUntypedConst(operand)
+		if err != nil {
+			return nil, err
+		}
+		return goconst.UnaryOp(gotoken.SUB, val, 0), nil
+	}
+
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok {
+		return nil, fmt.Errorf("unsupported expression %s", expr.String())
+	}
+
 	var goKind gotoken.Token
 	switch lit.Kind {
 	case token.INT:
@@ -202,10 +397,16 @@ var (
 	}
 )
 
-// isFitsInTypeRange returns nil if lit can be represented exactly as expectedType.
-func isFitsInTypeRange(lit *ast.BasicLit, expectedType string) error {
-	litStr := lit.Value
-	val, err := makeUntypedConst(lit)
+// isFitsInTypeRange returns nil if expr, a basic literal or a negated numeric
+// literal, can be represented exactly as expectedType.
+func isFitsInTypeRange(expr ast.Expr, expectedType string) error {
+	litStr := expr.String()
+	kind := token.ILLEGAL
+	if lit, ok := expr.(*ast.BasicLit); ok {
+		kind = lit.Kind
+	}
+
+	val, err := makeUntypedConst(expr)
 	if err != nil {
 		return fmt.Errorf("cannot parse literal %q: %v", litStr, err)
 	}
@@ -282,21 +483,21 @@ func isFitsInTypeRange(lit *ast.BasicLit, expectedType string) error {
 		return nil
 
 	case "true", "false", "bool":
-		if lit.Kind != token.TRUE && lit.Kind != token.FALSE {
+		if kind != token.TRUE && kind != token.FALSE {
 			return fmt.Errorf("literal %s is not a boolean literal", litStr)
 		}
 
 		return nil
 
 	case "string":
-		if lit.Kind != token.STRING {
+		if kind != token.STRING {
 			return fmt.Errorf("literal %s is not a string literal", litStr)
 		}
 
 		return nil
 
 	case "char":
-		if lit.Kind != token.CHAR {
+		if kind != token.CHAR {
 			return fmt.Errorf("literal %s is not a character literal", litStr)
 		}
 
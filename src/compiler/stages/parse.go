@@ -21,7 +21,7 @@ func (r *ParseStage) Name() string {
 }
 
 func (r *ParseStage) Process(ctx *compiler.Context) error {
-	lex := lexer.New(ctx.SourcePath, ctx.SourceCode, 0)
+	lex := lexer.New(ctx.SourcePath, ctx.SourceCode, lexer.CommentMode)
 
 	p := parser.New(lex)
 	file := p.Parse()
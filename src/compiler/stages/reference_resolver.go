@@ -0,0 +1,147 @@
+package stages
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kkumar-gcc/enumgen/src/ast"
+	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
+	"github.com/kkumar-gcc/enumgen/src/errors"
+	"github.com/kkumar-gcc/enumgen/src/token"
+)
+
+// ReferenceResolver binds the member references in enum values, such as
+// `ALIAS = PRIMARY` or `Status.ERROR`, to the members they name and reports
+// references that cannot be resolved or that form a cycle.
+type ReferenceResolver struct{}
+
+func NewReferenceResolver() *ReferenceResolver {
+	return &ReferenceResolver{}
+}
+
+func (r *ReferenceResolver) Name() string {
+	return "ReferenceResolver"
+}
+
+func (r *ReferenceResolver) Process(ctx *compiler.Context) error {
+	if ctx == nil || ctx.Symbols == nil {
+		return fmt.Errorf("context has no symbol table")
+	}
+
+	var enums []*ast.EnumDefinition
+	for _, decl := range ctx.AST.Declarations {
+		enumDef, ok := decl.(*ast.EnumDefinition)
+		if !ok {
+			continue
+		}
+		enums = append(enums, enumDef)
+
+		symbol := ctx.Symbols.LookupEnum(enumDef.Name.Name)
+		if symbol == nil || symbol.Node != enumDef {
+			continue
+		}
+
+		// Unqualified references name members of the enclosing enum.
+		ctx.Symbols.SetCurrentScope(symbol.Scope)
+		for _, member := range enumDef.Members {
+			for _, ref := range references(member.Value) {
+				r.resolve(ctx, enumDef, ref)
+			}
+		}
+	}
+	ctx.Symbols.SetCurrentScope(ctx.Symbols.GlobalScope())
+
+	r.checkCycles(ctx, enums)
+
+	return nil
+}
+
+func (r *ReferenceResolver) resolve(ctx *compiler.Context, enumDef *ast.EnumDefinition, ref *ast.RefExpr) {
+	targetEnum := enumDef
+	if ref.Enum != nil {
+		symbol := ctx.Symbols.LookupEnum(ref.Enum.Name)
+		if symbol == nil {
+			r.addError(ctx, ref.Enum.Pos(),
+				fmt.Sprintf("undefined enum %s in reference %s", ref.Enum.Name, ref.String()),
+				"reference a member of an enum declared in this file")
+			return
+		}
+		targetEnum = symbol.Node.(*ast.EnumDefinition)
+	}
+
+	symbol := ctx.Symbols.LookupQualified(ref.String())
+	if symbol == nil || symbol.Kind != compiler.SymbolEnumMember {
+		r.addError(ctx, ref.Name.Pos(),
+			fmt.Sprintf("undefined member %s of enum %s", ref.Name.Name, targetEnum.Name.Name),
+			"reference a declared member, qualified by its enum if it belongs to another enum, e.g. Status.ERROR")
+		return
+	}
+
+	ref.Target = symbol.Node.(*ast.MemberDefinition)
+	ref.TargetEnum = targetEnum
+}
+
+// checkCycles reports members whose value is a reference that, followed
+// through the members it names, leads back to the member itself.
+func (r *ReferenceResolver) checkCycles(ctx *compiler.Context, enums []*ast.EnumDefinition) {
+	reported := make(map[*ast.MemberDefinition]bool)
+
+	for _, enumDef := range enums {
+		for _, member := range enumDef.Members {
+			if reported[member] {
+				continue
+			}
+
+			path := []string{enumDef.Name.Name + "." + member.Name.Name}
+			visited := map[*ast.MemberDefinition]bool{member: true}
+			ref, _ := member.Value.(*ast.RefExpr)
+			for ref != nil && ref.Target != nil {
+				path = append(path, ref.TargetEnum.Name.Name+"."+ref.Target.Name.Name)
+				if ref.Target == member {
+					r.addError(ctx, member.Value.Pos(),
+						fmt.Sprintf("reference cycle: %s", strings.Join(path, " -> ")),
+						"assign a literal to one of the members in the cycle")
+					for m := range visited {
+						reported[m] = true
+					}
+					break
+				}
+				if visited[ref.Target] {
+					// The chain ends in a cycle that does not include this member.
+					break
+				}
+				visited[ref.Target] = true
+				ref, _ = ref.Target.Value.(*ast.RefExpr)
+			}
+		}
+	}
+}
+
+func (r *ReferenceResolver) addError(ctx *compiler.Context, pos token.Position, msg, fix string) {
+	ctx.Errors.Add(&errors.CompilationError{
+		Pos:      pos,
+		Msg:      msg,
+		Fix:      fix,
+		Severity: errors.SeverityError,
+		Stage:    r.Name(),
+		Filename: ctx.SourcePath,
+	})
+}
+
+// references returns the member references in a member value.
+func references(expr ast.Expr) []*ast.RefExpr {
+	switch e := expr.(type) {
+	case *ast.RefExpr:
+		return []*ast.RefExpr{e}
+	case *ast.KeyValueExpr:
+		return append(references(e.Key), references(e.Value)...)
+	case *ast.TupleExpr:
+		var refs []*ast.RefExpr
+		for _, elt := range e.Elts {
+			refs = append(refs, references(elt)...)
+		}
+		return refs
+	default:
+		return nil
+	}
+}
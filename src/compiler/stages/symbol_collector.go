@@ -2,6 +2,7 @@ package stages
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/kkumar-gcc/enumgen/src/ast"
 	"github.com/kkumar-gcc/enumgen/src/compiler/symbols"
@@ -51,17 +52,12 @@ func (r *SymbolCollector) processEnum(ctx *compiler.Context, enumDef *ast.EnumDe
 		return
 	}
 
-	docString := ""
-	if enumDef.Doc != nil && len(enumDef.Doc.List) > 0 {
-		docString = enumDef.Doc.String()
-	}
-
 	enumSymbol := &compiler.Symbol{
 		Name:      enumName,
 		Kind:      compiler.SymbolEnum,
 		Node:      enumDef,
 		Pos:       enumPos,
-		Docstring: docString,
+		Docstring: enumDef.DocText(),
 	}
 
 	if err := ctx.Symbols.Define(enumSymbol); err != nil {
@@ -98,17 +94,12 @@ func (r *SymbolCollector) processEnum(ctx *compiler.Context, enumDef *ast.EnumDe
 
 		seenMembers[memberName] = memberPos
 
-		docString := ""
-		if member.Doc != nil && len(member.Doc.List) > 0 {
-			docString = member.Doc.String()
-		}
-
 		memberSymbol := &compiler.Symbol{
 			Name:      memberName,
 			Kind:      compiler.SymbolEnumMember,
 			Node:      member,
 			Pos:       memberPos,
-			Docstring: docString,
+			Docstring: member.DocText(),
 		}
 
 		if err := enumScope.Define(memberSymbol); err != nil {
@@ -123,4 +114,123 @@ func (r *SymbolCollector) processEnum(ctx *compiler.Context, enumDef *ast.EnumDe
 	}
 
 	ctx.Symbols.ExitScope()
+
+	r.checkAliases(ctx, enumDef)
+	r.checkJSONNames(ctx, enumDef)
+}
+
+// checkAliases reports aliases that repeat the key or alias of any member of the enum.
+func (r *SymbolCollector) checkAliases(ctx *compiler.Context, enumDef *ast.EnumDefinition) {
+	type keyOwner struct {
+		member string
+		pos    token.Position
+	}
+	keys := make(map[string]keyOwner)
+
+	for _, member := range enumDef.Members {
+		if key, ok := stringKey(enumDef, member); ok {
+			if _, exists := keys[key]; !exists {
+				keys[key] = keyOwner{member: member.Name.Name, pos: member.Pos()}
+			}
+		}
+	}
+
+	for _, member := range enumDef.Members {
+		for _, alias := range member.Aliases {
+			value, err := strconv.Unquote(alias.Value)
+			if err != nil {
+				continue
+			}
+
+			if prev, exists := keys[value]; exists {
+				ctx.Errors.Add(&errors.CompilationError{
+					Pos: alias.Pos(),
+					Msg: fmt.Sprintf("alias %s of member %s in enum %s is already used by member %s at %v",
+						alias.Value, member.Name.Name, enumDef.Name.Name, prev.member, prev.pos),
+					Fix:      "give each alias a value that is not a key or alias of any other member",
+					Severity: errors.SeverityError,
+					Stage:    r.Name(),
+					Filename: ctx.SourcePath,
+				})
+				continue
+			}
+			keys[value] = keyOwner{member: member.Name.Name, pos: alias.Pos()}
+		}
+	}
+}
+
+// stringKey returns the key of a member when it is a string. Members of flags
+// and tuple enums are keyed by their names, as are members without a value.
+// Members aliasing another member have no key of their own.
+func stringKey(enumDef *ast.EnumDefinition, member *ast.MemberDefinition) (string, bool) {
+	if enumDef.IsAlias(member) {
+		return "", false
+	}
+	if enumDef.IsFlags() || enumDef.IsTuple() || member.Value == nil {
+		return member.Name.Name, true
+	}
+
+	expr := member.Value
+	if kv, ok := expr.(*ast.KeyValueExpr); ok {
+		expr = kv.Key
+	}
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	key, err := strconv.Unquote(lit.Value)
+	return key, err == nil
+}
+
+// checkJSONNames reports members whose JSON form, given by '@json' or else
+// their key, is already used by another member of the enum.
+func (r *SymbolCollector) checkJSONNames(ctx *compiler.Context, enumDef *ast.EnumDefinition) {
+	names := make(map[string]string)
+	report := func(member *ast.MemberDefinition, name, owner string) {
+		ctx.Errors.Add(&errors.CompilationError{
+			Pos: member.Pos(),
+			Msg: fmt.Sprintf("JSON name %q of member %s in enum %s is already used by member %s",
+				name, member.Name.Name, enumDef.Name.Name, owner),
+			Fix:      "give each member a JSON name that no other member uses",
+			Severity: errors.SeverityError,
+			Stage:    r.Name(),
+			Filename: ctx.SourcePath,
+		})
+	}
+
+	for _, member := range enumDef.Members {
+		a := member.Annotation("json")
+		if a == nil || len(a.Args) != 1 {
+			continue
+		}
+		lit, ok := a.Args[0].(*ast.BasicLit)
+		if !ok {
+			continue
+		}
+		name, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			continue
+		}
+		if owner, exists := names[name]; exists {
+			report(member, name, owner)
+			continue
+		}
+		names[name] = member.Name.Name
+	}
+	if len(names) == 0 {
+		return
+	}
+
+	for _, member := range enumDef.Members {
+		if member.Annotation("json") != nil {
+			continue
+		}
+		key, ok := stringKey(enumDef, member)
+		if !ok {
+			continue
+		}
+		if owner, exists := names[key]; exists {
+			report(member, key, owner)
+		}
+	}
 }
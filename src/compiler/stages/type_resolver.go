@@ -7,8 +7,13 @@ import (
 	"github.com/kkumar-gcc/enumgen/src/compiler/types"
 	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
 	"github.com/kkumar-gcc/enumgen/src/errors"
+	"github.com/kkumar-gcc/enumgen/src/token"
 )
 
+// OptionDefaultType names the file-level option that selects the value type of
+// enums declared without a type specification, e.g. `option default_type = int;`.
+const OptionDefaultType = "default_type"
+
 type TypeResolver struct{}
 
 func NewTypeResolver() *TypeResolver {
@@ -37,6 +42,8 @@ func (r *TypeResolver) Process(ctx *compiler.Context) error {
 		}
 	}
 
+	defaultType := r.resolveDefaultType(ctx, stringType)
+
 	for _, decl := range ctx.AST.Declarations {
 		enumDecl, ok := decl.(*ast.EnumDefinition)
 		if !ok {
@@ -57,7 +64,13 @@ func (r *TypeResolver) Process(ctx *compiler.Context) error {
 		}
 
 		enumType := types.NewType(compiler.TypeEnum, enumName, enumDecl)
-		if enumDecl.TypeSpec != nil {
+		enumType.SetEnumSymbol(enumSymbol)
+		if enumDecl.IsTuple() {
+			// Tuple enums carry no single value type; each field is resolved on its own.
+			if !r.resolveFields(ctx, enumDecl.TypeSpec.Fields) {
+				continue
+			}
+		} else if enumDecl.TypeSpec != nil {
 			if len(enumDecl.TypeSpec.Types) == 0 {
 				ctx.Errors.Add(&errors.CompilationError{
 					Pos:      enumDecl.TypeSpec.Pos(),
@@ -118,7 +131,7 @@ func (r *TypeResolver) Process(ctx *compiler.Context) error {
 				enumType.SetValueType(valueType)
 			}
 		} else {
-			enumType.SetValueType(stringType)
+			enumType.SetValueType(defaultType)
 		}
 
 		enumSymbol.Type = enumType
@@ -133,9 +146,131 @@ func (r *TypeResolver) Process(ctx *compiler.Context) error {
 		}
 	}
 
+	r.checkFieldCycles(ctx)
+
 	return nil
 }
 
+// resolveFields reports the fields of a tuple enum whose types are unknown and
+// returns whether all of them resolved. A field may have the type of an enum
+// declared anywhere in the file.
+func (r *TypeResolver) resolveFields(ctx *compiler.Context, fields []*ast.Field) bool {
+	ok := true
+	for _, field := range fields {
+		if r.resolveTypeRef(ctx, field.Type) != nil || ctx.Symbols.LookupEnum(field.Type.Name.Name) != nil {
+			continue
+		}
+		ctx.Errors.Add(&errors.CompilationError{
+			Pos:      field.Type.Pos(),
+			Msg:      fmt.Sprintf("unknown type %s of field %s", field.Type.Name.Name, field.Name.Name),
+			Severity: errors.SeverityError,
+			Stage:    r.Name(),
+			Filename: ctx.SourcePath,
+		})
+		ok = false
+	}
+	return ok
+}
+
+// checkFieldCycles reports tuple enums that contain themselves through the
+// types of their fields, such as an enum with a field of its own type.
+func (r *TypeResolver) checkFieldCycles(ctx *compiler.Context) {
+	var order []*ast.EnumDefinition
+	tuples := make(map[string]*ast.EnumDefinition)
+	for _, decl := range ctx.AST.Declarations {
+		if enumDecl, ok := decl.(*ast.EnumDefinition); ok && enumDecl.IsTuple() {
+			order = append(order, enumDecl)
+			tuples[enumDecl.Name.Name] = enumDecl
+		}
+	}
+
+	var contains func(enumDecl *ast.EnumDefinition, target string, seen map[string]bool) bool
+	contains = func(enumDecl *ast.EnumDefinition, target string, seen map[string]bool) bool {
+		for _, field := range enumDecl.TypeSpec.Fields {
+			name := field.Type.Name.Name
+			if name == target {
+				return true
+			}
+			if next, ok := tuples[name]; ok && !seen[name] {
+				seen[name] = true
+				if contains(next, target, seen) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	for _, enumDecl := range order {
+		name := enumDecl.Name.Name
+		for _, field := range enumDecl.TypeSpec.Fields {
+			fieldEnum, ok := tuples[field.Type.Name.Name]
+			if !ok || (fieldEnum != enumDecl && !contains(fieldEnum, name, map[string]bool{})) {
+				continue
+			}
+			msg := fmt.Sprintf("field %s of enum %s has type %s, which contains %s", field.Name.Name, name, fieldEnum.Name.Name, name)
+			if fieldEnum == enumDecl {
+				msg = fmt.Sprintf("field %s of enum %s cannot have the type of its own enum", field.Name.Name, name)
+			}
+			ctx.Errors.Add(&errors.CompilationError{
+				Pos:      field.Type.Pos(),
+				Msg:      msg,
+				Fix:      "use a type that does not contain the enum, such as a non-tuple enum",
+				Severity: errors.SeverityError,
+				Stage:    r.Name(),
+				Filename: ctx.SourcePath,
+			})
+		}
+	}
+}
+
+// resolveDefaultType returns the value type for enums without a type specification.
+// Members of such enums default to their own names as string values, unless the
+// file selects an integer type to number them sequentially instead.
+func (r *TypeResolver) resolveDefaultType(ctx *compiler.Context, stringType compiler.Type) compiler.Type {
+	defaultType := stringType
+
+	for _, decl := range ctx.AST.Declarations {
+		opt, ok := decl.(*ast.OptionDecl)
+		if !ok {
+			continue
+		}
+
+		if opt.Name.Name != OptionDefaultType {
+			ctx.Errors.Add(&errors.CompilationError{
+				Pos:      opt.Name.Pos(),
+				Msg:      fmt.Sprintf("unknown option %s", opt.Name.Name),
+				Fix:      fmt.Sprintf("supported options: %s", OptionDefaultType),
+				Severity: errors.SeverityWarning,
+				Stage:    r.Name(),
+				Filename: ctx.SourcePath,
+			})
+			continue
+		}
+
+		typeName := ""
+		if ident, ok := opt.Value.(*ast.BasicLit); ok && ident.Kind == token.IDENT {
+			typeName = ident.Value
+		}
+
+		resolved := r.resolveTypeRef(ctx, &ast.TypeRef{Name: ast.Ident{Name: typeName}})
+		if resolved == nil || (typeName != "string" && !types.IsIntegerType(typeName)) {
+			ctx.Errors.Add(&errors.CompilationError{
+				Pos:      opt.Value.Pos(),
+				Msg:      fmt.Sprintf("invalid value %s for option %s", opt.Value.String(), OptionDefaultType),
+				Fix:      "use string to default members to their names, or an integer type such as int to number them sequentially",
+				Severity: errors.SeverityError,
+				Stage:    r.Name(),
+				Filename: ctx.SourcePath,
+			})
+			continue
+		}
+		defaultType = resolved
+	}
+
+	return defaultType
+}
+
 func (r *TypeResolver) resolveTypeRef(ctx *compiler.Context, typeRef *ast.TypeRef) compiler.Type {
 	if typeRef == nil {
 		return nil
@@ -1,6 +1,10 @@
 package symbols
 
-import "github.com/kkumar-gcc/enumgen/src/contracts/compiler"
+import (
+	"strings"
+
+	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
+)
 
 type Table struct {
 	globalScope  compiler.Scope
@@ -69,3 +73,19 @@ func (r *Table) LookupEnum(name string) *compiler.Symbol {
 	}
 	return nil
 }
+
+// LookupQualified resolves a possibly qualified name. A name of the form
+// "Enum.MEMBER" is looked up among the members of that enum; any other name is
+// looked up from the current scope outwards.
+func (r *Table) LookupQualified(name string) *compiler.Symbol {
+	enumName, memberName, qualified := strings.Cut(name, ".")
+	if !qualified {
+		return r.currentScope.LookupQualified(name)
+	}
+
+	enum := r.LookupEnum(enumName)
+	if enum == nil || enum.Scope == nil {
+		return nil
+	}
+	return enum.Scope.LookupLocal(memberName)
+}
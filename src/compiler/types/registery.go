@@ -2,6 +2,8 @@ package types
 
 import (
 	"fmt"
+	"maps"
+
 	"github.com/kkumar-gcc/enumgen/src/contracts/compiler"
 )
 
@@ -16,7 +18,7 @@ type Registry struct {
 func NewRegistry() *Registry {
 	return &Registry{
 		primitives: primitives,
-		types:      primitives,
+		types:      maps.Clone(primitives),
 	}
 }
 
@@ -63,3 +65,12 @@ func IsPrimitiveType(typeName string) bool {
 	_, ok := primitives[typeName]
 	return ok
 }
+
+func IsIntegerType(typeName string) bool {
+	switch typeName {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return true
+	default:
+		return false
+	}
+}
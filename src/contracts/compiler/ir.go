@@ -7,11 +7,13 @@ import (
 
 type IRModule interface {
 	Name() string
+	Doc() string
 	Enums() []IREnumDefinition
 	Source() string
 	SetSource(source string) IRModule
 	SetEnums(enums []IREnumDefinition) IRModule
 	SetName(name string) IRModule
+	SetDoc(doc string) IRModule
 }
 
 type IRValue interface {
@@ -21,6 +23,7 @@ type IRValue interface {
 
 type IRUnary interface {
 	IRValue
+	Operator() token.Token
 	Operand() IRValue
 }
 
@@ -30,6 +33,18 @@ type IRKeyValue interface {
 	Value() IRValue
 }
 
+type IRTuple interface {
+	IRValue
+	Elements() []IRValue
+}
+
+type IRReference interface {
+	IRValue
+	Enum() string
+	Member() string
+	Target() IRValue
+}
+
 type IRLiteral interface {
 	IRValue
 	Value() string
@@ -37,21 +52,45 @@ type IRLiteral interface {
 	TypeInfo() Type
 }
 
+type IRAnnotation interface {
+	Name() string
+	Args() []string
+	Position() token.Position
+	OriginalNode() *ast.Annotation
+}
+
+type IRField interface {
+	Name() string
+	Type() Type
+	IsUnique() bool
+	Position() token.Position
+	OriginalNode() *ast.Field
+}
+
 type IREnumDefinition interface {
 	Name() string
 	Doc() string
+	Annotations() []IRAnnotation
 	Members() []IREnumMember
+	MemberAliases() []IREnumMember
+	Fields() []IRField
 	ValueType() Type
 	KeyType() Type
+	IsFlags() bool
 	Position() token.Position
 	OriginalNode() *ast.EnumDefinition
 	FindMember(name string) IREnumMember
+	DefaultMember() IREnumMember
 }
 
 type IREnumMember interface {
 	Name() string
 	Doc() string
+	Comment() string
 	Value() IRValue
+	Aliases() []string
+	Annotations() []IRAnnotation
+	IsDefault() bool
 	Position() token.Position
 	OriginalNode() *ast.MemberDefinition
 }
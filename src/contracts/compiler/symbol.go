@@ -9,6 +9,7 @@ type SymbolTable interface {
 	Define(symbol *Symbol) error
 	Lookup(name string) *Symbol
 	LookupEnum(name string) *Symbol
+	LookupQualified(name string) *Symbol
 	CurrentScope() Scope
 	SetCurrentScope(scope Scope)
 	GlobalScope() Scope
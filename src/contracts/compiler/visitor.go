@@ -8,6 +8,7 @@ type Visitor interface {
 	VisitTypeSpec(node *ast.TypeSpec) any
 	VisitTypeRef(node *ast.TypeRef) any
 	VisitMember(node *ast.MemberDefinition) any
+	VisitAnnotation(node *ast.Annotation) any
 	VisitBasicLit(node *ast.BasicLit) any
 	VisitKeyValueExpr(node *ast.KeyValueExpr) any
 	VisitIdent(node *ast.Ident) any
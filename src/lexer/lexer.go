@@ -134,6 +134,10 @@ scanAgain:
 			tok = token.ASSIGN
 		case '-':
 			tok = token.SUB
+		case '@':
+			tok = token.AT
+		case '.':
+			tok = token.PERIOD
 		case ';':
 			tok = token.SEMICOLON
 			lit = ";"
@@ -141,6 +145,10 @@ scanAgain:
 			tok = token.LBRACKET
 		case ']':
 			tok = token.RBRACKET
+		case '(':
+			tok = token.LPAREN
+		case ')':
+			tok = token.RPAREN
 		default:
 			tok = token.ILLEGAL
 			lit = string(ch)
@@ -15,6 +15,11 @@ type Parser struct {
 	pos token.Position
 	tok token.Token
 	lit string
+
+	// Comments are only produced when the lexer runs in lexer.CommentMode.
+	comments    []*ast.CommentGroup
+	leadComment *ast.CommentGroup // comment group ending on the line before the current token
+	lineComment *ast.CommentGroup // comment group on the same line as the previous token
 }
 
 func New(l *lexer.Lexer) *Parser {
@@ -23,14 +28,66 @@ func New(l *lexer.Lexer) *Parser {
 	return p
 }
 
+// next advances to the next non-comment token. Comments in between are
+// collected into groups and classified as the line comment of the previous
+// token and/or the lead comment of the current one.
 func (p *Parser) next() {
+	p.leadComment = nil
+	p.lineComment = nil
+	prevLine := p.pos.Line
 	p.pos, p.tok, p.lit = p.l.Lex()
+
+	if !p.tokenIs(token.COMMENT) {
+		return
+	}
+
+	var comment *ast.CommentGroup
+	var endLine int
+
+	if prevLine > 0 && p.pos.Line == prevLine {
+		// The comment is on the same line as the previous token; it
+		// cannot be a lead comment but may be a line comment.
+		comment, endLine = p.consumeCommentGroup(0)
+		if p.pos.Line != endLine || p.tokenIs(token.EOF) {
+			p.lineComment = comment
+		}
+	}
+
+	endLine = -1
+	for p.tokenIs(token.COMMENT) {
+		comment, endLine = p.consumeCommentGroup(1)
+	}
+
+	if endLine+1 == p.pos.Line {
+		p.leadComment = comment
+	}
+}
+
+// consumeCommentGroup consumes adjacent comments that are at most n lines
+// apart and returns them as a group along with the line of the last comment.
+func (p *Parser) consumeCommentGroup(n int) (*ast.CommentGroup, int) {
+	group := &ast.CommentGroup{}
+	endLine := p.pos.Line
+	for p.tokenIs(token.COMMENT) && p.pos.Line <= endLine+n {
+		group.Add(&ast.Comment{Slash: p.pos, Text: p.lit})
+		endLine = p.pos.Line
+		p.pos, p.tok, p.lit = p.l.Lex()
+	}
+	p.comments = append(p.comments, group)
+	return group, endLine
 }
 
 func (p *Parser) tokenIs(tok token.Token) bool {
 	return p.tok == tok
 }
 
+// identIs reports whether the current token is the identifier name, which is
+// how contextual keywords such as 'option', 'flags' and 'alias' are
+// recognised.
+func (p *Parser) identIs(name string) bool {
+	return p.tok == token.IDENT && p.lit == name
+}
+
 func (p *Parser) expect(tok token.Token, msg string) bool {
 	if p.tokenIs(tok) {
 		p.next()
@@ -55,43 +112,76 @@ func (p *Parser) Parse() *ast.File {
 		Comments:     []*ast.CommentGroup{},
 	}
 
-	for !p.tokenIs(token.EOF) {
-		if p.tokenIs(token.COMMENT) {
-			cg := p.consumeComments()
-			file.Comments = append(file.Comments, cg)
-			continue
-		}
+	// A leading comment group that is not attached to the first declaration documents the file.
+	if len(p.comments) > 0 && p.comments[0] != p.leadComment {
+		file.Doc = p.comments[0]
+	}
 
-		if p.tokenIs(token.ENUM) {
+	for !p.tokenIs(token.EOF) {
+		if p.identIs("option") {
+			decl := p.parseOption()
+			file.Declarations = append(file.Declarations, decl)
+			p.skipToDeclaration()
+		} else if p.tokenIs(token.ENUM) || p.tokenIs(token.AT) {
 			decl := p.parseEnum()
 			file.Declarations = append(file.Declarations, decl)
-
-			// Skip any extra tokens until we're at a position to parse a new declaration
-			for !p.tokenIs(token.EOF) && !p.tokenIs(token.ENUM) && !p.tokenIs(token.COMMENT) {
-				p.next()
-			}
+			p.skipToDeclaration()
 		} else {
 			p.errorExpected("enum declaration")
 			p.next()
 		}
 	}
 
+	file.Comments = append(file.Comments, p.comments...)
 	file.FileEnd = p.pos
 	return file
 }
 
-func (p *Parser) consumeComments() *ast.CommentGroup {
-	group := &ast.CommentGroup{}
-	for p.tokenIs(token.COMMENT) {
-		group.Add(&ast.Comment{Slash: p.pos, Text: p.lit})
+// skipToDeclaration skips any extra tokens until we're at a position to parse a new declaration.
+func (p *Parser) skipToDeclaration() {
+	for !p.tokenIs(token.EOF) && !p.tokenIs(token.ENUM) && !p.tokenIs(token.AT) && !p.identIs("option") {
 		p.next()
 	}
-	return group
 }
 
-// EnumDefinition ::= { Comment } 'enum' Identifier [ TypeSpec ] MemberList
+// Option ::= 'option' Identifier '=' Literal ';'
+func (p *Parser) parseOption() *ast.OptionDecl {
+	opt := &ast.OptionDecl{Doc: p.leadComment, OptionPos: p.pos}
+	p.next()
+
+	if !p.tokenIs(token.IDENT) {
+		p.errorExpected("option name")
+		return opt
+	}
+	opt.Name = ast.Ident{NamePos: p.pos, Name: p.lit}
+	p.next()
+
+	if !p.tokenIs(token.ASSIGN) {
+		p.errorExpected("'=' after option name")
+		return opt
+	}
+	opt.AssignPos = p.pos
+	p.next()
+
+	opt.Value = p.parseLiteral("option value")
+	if opt.Value == nil {
+		return opt
+	}
+
+	if !p.tokenIs(token.SEMICOLON) {
+		p.errorExpected("';' after option")
+		return opt
+	}
+	opt.TermPos = p.pos
+	p.next()
+
+	return opt
+}
+
+// EnumDefinition ::= { Comment } { Annotation } 'enum' Identifier [ TypeSpec ] [ 'flags' ] ':' MemberList
 func (p *Parser) parseEnum() *ast.EnumDefinition {
-	enum := &ast.EnumDefinition{Doc: p.consumeComments()}
+	enum := &ast.EnumDefinition{Doc: p.leadComment}
+	enum.Annotations = p.parseAnnotations()
 	if !p.expect(token.ENUM, "enum") {
 		return enum
 	}
@@ -104,11 +194,15 @@ func (p *Parser) parseEnum() *ast.EnumDefinition {
 	enum.Name = ast.Ident{NamePos: p.pos, Name: p.lit}
 	p.next()
 
-	if !p.tokenIs(token.LBRACKET) {
-		p.errorExpected("'['")
-		return enum
+	if p.tokenIs(token.LBRACKET) {
+		enum.TypeSpec = p.parseTypeSpec()
+	}
+
+	// 'flags' is only a modifier here, so it stays available as a name.
+	if p.identIs("flags") {
+		enum.FlagsPos = p.pos
+		p.next()
 	}
-	enum.TypeSpec = p.parseTypeSpec()
 
 	if !p.expect(token.COLON, "':' after enum declaration") {
 		// Skip to next potential valid token
@@ -117,40 +211,41 @@ func (p *Parser) parseEnum() *ast.EnumDefinition {
 		}
 		return enum
 	}
+	enum.Comment = p.lineComment
 
 	for {
-		// Consume any comments before the member
-		if p.tokenIs(token.COMMENT) {
-			p.consumeComments()
-			continue
-		}
-
-		if p.tokenIs(token.IDENT) {
+		if p.tokenIs(token.IDENT) || p.tokenIs(token.AT) {
 			member := p.parseMember()
+			if member == nil {
+				p.skipPastSemicolon()
+				return enum
+			}
+
+			if member.TermPos.IsValid() {
+				// The member's aliases already consumed the terminating comma.
+				enum.Members = append(enum.Members, member)
+				continue
+			}
 
 			switch p.tok {
 			case token.COMMA:
 				member.TermPos = p.pos
 				enum.Members = append(enum.Members, member)
 				p.next()
+				member.Comment = p.lineComment
 				continue
 
 			case token.SEMICOLON:
 				member.TermPos = p.pos
 				enum.Members = append(enum.Members, member)
 				p.next()
+				member.Comment = p.lineComment
 				return enum
 
 			default:
 				p.errorExpected("',' or ';' after enum member")
 				enum.Members = append(enum.Members, member)
-				// Try to recover by skipping to next semicolon or enum
-				for !p.tokenIs(token.EOF) && !p.tokenIs(token.SEMICOLON) && !p.tokenIs(token.ENUM) {
-					p.next()
-				}
-				if p.tokenIs(token.SEMICOLON) {
-					p.next()
-				}
+				p.skipPastSemicolon()
 				return enum
 			}
 		}
@@ -158,13 +253,7 @@ func (p *Parser) parseEnum() *ast.EnumDefinition {
 		// If we get here, we couldn't parse any more members
 		if !p.tokenIs(token.SEMICOLON) && !p.tokenIs(token.EOF) {
 			p.errorExpected("';' after enum declaration")
-			// Try to recover by skipping to next semicolon or enum
-			for !p.tokenIs(token.EOF) && !p.tokenIs(token.SEMICOLON) && !p.tokenIs(token.ENUM) {
-				p.next()
-			}
-			if p.tokenIs(token.SEMICOLON) {
-				p.next()
-			}
+			p.skipPastSemicolon()
 		} else if p.tokenIs(token.SEMICOLON) {
 			p.next()
 		}
@@ -173,7 +262,18 @@ func (p *Parser) parseEnum() *ast.EnumDefinition {
 	}
 }
 
-// TypeSpec ::= '[' Type { ',' Type } ']'
+// skipPastSemicolon recovers from an error inside an enum by skipping to the
+// next semicolon, which is consumed, or to the next enum declaration.
+func (p *Parser) skipPastSemicolon() {
+	for !p.tokenIs(token.EOF) && !p.tokenIs(token.SEMICOLON) && !p.tokenIs(token.ENUM) {
+		p.next()
+	}
+	if p.tokenIs(token.SEMICOLON) {
+		p.next()
+	}
+}
+
+// TypeSpec ::= '[' ( Type { ',' Type } | Field { ',' Field } ) ']'
 func (p *Parser) parseTypeSpec() *ast.TypeSpec {
 	ts := &ast.TypeSpec{LbrackPos: p.pos}
 	p.next()
@@ -183,25 +283,23 @@ func (p *Parser) parseTypeSpec() *ast.TypeSpec {
 			p.errorExpected("type identifier")
 			break
 		}
-		tr := &ast.TypeRef{Name: ast.Ident{NamePos: p.pos, Name: p.lit}}
+		first := ast.Ident{NamePos: p.pos, Name: p.lit}
 		p.next()
 
-		for p.tokenIs(token.PERIOD) {
-			p.next()
-			if !p.tokenIs(token.IDENT) {
-				p.errorExpected("identifier after dot")
-				break
+		if p.tokenIs(token.IDENT) {
+			// A name followed by a type declares a named tuple field.
+			field := p.parseField(first)
+			if len(ts.Types) > 0 {
+				p.err.Add(field.Pos(), "cannot mix named fields and unnamed types in a type specification")
 			}
-			tr = &ast.TypeRef{
-				Package: &tr.Name,
-				DotPos:  p.pos,
-				Name:    ast.Ident{NamePos: p.pos, Name: p.lit},
+			ts.Fields = append(ts.Fields, field)
+		} else {
+			if len(ts.Fields) > 0 {
+				p.err.Add(first.Pos(), "cannot mix named fields and unnamed types in a type specification")
 			}
-			p.next()
+			ts.Types = append(ts.Types, p.parseTypeRef(first))
 		}
 
-		ts.Types = append(ts.Types, tr)
-
 		if !p.tokenIs(token.COMMA) {
 			break
 		}
@@ -218,9 +316,49 @@ func (p *Parser) parseTypeSpec() *ast.TypeSpec {
 	return ts
 }
 
-// MemberDefinition ::= { Comment } Identifier [ MemberAssignment ] [ Terminator ]
+// Field ::= Identifier Type [ 'unique' ]
+func (p *Parser) parseField(name ast.Ident) *ast.Field {
+	field := &ast.Field{Name: name}
+
+	typeName := ast.Ident{NamePos: p.pos, Name: p.lit}
+	p.next()
+	field.Type = p.parseTypeRef(typeName)
+
+	if p.tokenIs(token.IDENT) && p.lit == "unique" {
+		field.UniquePos = p.pos
+		p.next()
+	}
+
+	return field
+}
+
+// Type ::= Identifier { '.' Identifier }
+//
+// The first identifier has already been consumed and is passed in as name.
+func (p *Parser) parseTypeRef(name ast.Ident) *ast.TypeRef {
+	tr := &ast.TypeRef{Name: name}
+
+	for p.tokenIs(token.PERIOD) {
+		p.next()
+		if !p.tokenIs(token.IDENT) {
+			p.errorExpected("identifier after dot")
+			break
+		}
+		tr = &ast.TypeRef{
+			Package: &tr.Name,
+			DotPos:  p.pos,
+			Name:    ast.Ident{NamePos: p.pos, Name: p.lit},
+		}
+		p.next()
+	}
+
+	return tr
+}
+
+// MemberDefinition ::= { Comment } { Annotation } Identifier [ MemberAssignment ] [ Aliases ] [ Terminator ]
 func (p *Parser) parseMember() *ast.MemberDefinition {
-	lead := p.consumeComments()
+	lead := p.leadComment
+	annotations := p.parseAnnotations()
 
 	if !p.tokenIs(token.IDENT) {
 		p.errorExpected("identifier")
@@ -229,8 +367,9 @@ func (p *Parser) parseMember() *ast.MemberDefinition {
 
 	// Use saved position for member name
 	m := &ast.MemberDefinition{
-		Doc:  lead,
-		Name: ast.Ident{NamePos: p.pos, Name: p.lit},
+		Doc:         lead,
+		Annotations: annotations,
+		Name:        ast.Ident{NamePos: p.pos, Name: p.lit},
 	}
 	p.next()
 
@@ -238,31 +377,194 @@ func (p *Parser) parseMember() *ast.MemberDefinition {
 		m.AssignPos = p.pos
 		p.next()
 
-		if p.isLiteral(p.tok) {
-			lit1 := &ast.BasicLit{ValuePos: p.pos, Kind: p.tok, Value: p.lit}
-			p.next()
+		if p.tokenIs(token.LPAREN) {
+			m.Value = p.parseTuple()
+			if p.identIs("alias") {
+				p.parseAliases(m)
+			}
+			return m
+		}
 
-			if p.tokenIs(token.COLON) {
-				colonPos := p.pos
-				p.next()
-				if !p.isLiteral(p.tok) {
-					p.errorExpected("literal after ':'")
-					return m
-				}
-				lit2 := &ast.BasicLit{ValuePos: p.pos, Kind: p.tok, Value: p.lit}
-				m.Value = &ast.KeyValueExpr{Key: lit1, Colon: colonPos, Value: lit2}
-				p.next()
-			} else {
-				m.Value = lit1
+		lit1 := p.parseValue("literal")
+		if lit1 == nil {
+			return m
+		}
+
+		if p.tokenIs(token.COLON) {
+			colonPos := p.pos
+			p.next()
+			lit2 := p.parseValue("literal after ':'")
+			if lit2 == nil {
+				return m
 			}
+			m.Value = &ast.KeyValueExpr{Key: lit1, Colon: colonPos, Value: lit2}
 		} else {
-			p.errorExpected("literal")
+			m.Value = lit1
 		}
 	}
 
+	if p.identIs("alias") {
+		p.parseAliases(m)
+	}
+
 	return m
 }
 
+// Aliases ::= 'alias' STRING { ',' STRING }
+//
+// A comma after an alias either continues the list or terminates the member.
+// In the latter case the comma is consumed here and recorded as TermPos.
+func (p *Parser) parseAliases(m *ast.MemberDefinition) {
+	m.AliasPos = p.pos
+	p.next()
+
+	for {
+		if !p.tokenIs(token.STRING) {
+			p.errorExpected("string literal after 'alias'")
+			return
+		}
+		m.Aliases = append(m.Aliases, &ast.BasicLit{ValuePos: p.pos, Kind: p.tok, Value: p.lit})
+		p.next()
+
+		if !p.tokenIs(token.COMMA) {
+			return
+		}
+		commaPos := p.pos
+		p.next()
+		if !p.tokenIs(token.STRING) {
+			m.TermPos = commaPos
+			m.Comment = p.lineComment
+			return
+		}
+	}
+}
+
+// Tuple ::= '(' Literal { ',' Literal } ')'
+func (p *Parser) parseTuple() *ast.TupleExpr {
+	tuple := &ast.TupleExpr{Lparen: p.pos}
+	p.next()
+
+	for {
+		elt := p.parseValue("tuple value")
+		if elt == nil {
+			break
+		}
+		tuple.Elts = append(tuple.Elts, elt)
+
+		if !p.tokenIs(token.COMMA) {
+			break
+		}
+		p.next()
+	}
+
+	if p.tokenIs(token.RPAREN) {
+		tuple.Rparen = p.pos
+		p.next()
+	} else {
+		p.errorExpected("')' after tuple values")
+	}
+
+	return tuple
+}
+
+// Annotation ::= '@' Identifier [ '(' [ Literal { ',' Literal } ] ')' ]
+func (p *Parser) parseAnnotations() []*ast.Annotation {
+	var annotations []*ast.Annotation
+
+	for p.tokenIs(token.AT) {
+		a := &ast.Annotation{At: p.pos}
+		p.next()
+
+		if !p.tokenIs(token.IDENT) {
+			p.errorExpected("annotation name after '@'")
+			return annotations
+		}
+		a.Name = ast.Ident{NamePos: p.pos, Name: p.lit}
+		p.next()
+		annotations = append(annotations, a)
+
+		if !p.tokenIs(token.LPAREN) {
+			continue
+		}
+		a.Lparen = p.pos
+		p.next()
+
+		for !p.tokenIs(token.RPAREN) {
+			arg := p.parseLiteral("annotation argument")
+			if arg == nil {
+				return annotations
+			}
+			a.Args = append(a.Args, arg)
+
+			if !p.tokenIs(token.COMMA) {
+				break
+			}
+			p.next()
+		}
+
+		if !p.tokenIs(token.RPAREN) {
+			p.errorExpected("')' after annotation arguments")
+			return annotations
+		}
+		a.Rparen = p.pos
+		p.next()
+	}
+
+	return annotations
+}
+
+// Literal ::= [ '-' ] ( INT | FLOAT ) | CHAR | STRING | Identifier
+// Value ::= Literal | Reference
+func (p *Parser) parseValue(msg string) ast.Expr {
+	if p.tokenIs(token.IDENT) {
+		return p.parseReference()
+	}
+	return p.parseLiteral(msg)
+}
+
+// Reference ::= Identifier [ '.' Identifier ]
+func (p *Parser) parseReference() *ast.RefExpr {
+	ref := &ast.RefExpr{Name: ast.Ident{NamePos: p.pos, Name: p.lit}}
+	p.next()
+
+	if p.tokenIs(token.PERIOD) {
+		ref.DotPos = p.pos
+		p.next()
+		if !p.tokenIs(token.IDENT) {
+			p.errorExpected("member name after '.'")
+			return ref
+		}
+		enum := ref.Name
+		ref.Enum = &enum
+		ref.Name = ast.Ident{NamePos: p.pos, Name: p.lit}
+		p.next()
+	}
+
+	return ref
+}
+
+func (p *Parser) parseLiteral(msg string) ast.Expr {
+	if p.tokenIs(token.SUB) {
+		opPos := p.pos
+		p.next()
+		if !p.tokenIs(token.INT) && !p.tokenIs(token.FLOAT) {
+			p.errorExpected("numeric literal after '-'")
+			return nil
+		}
+		x := &ast.BasicLit{ValuePos: p.pos, Kind: p.tok, Value: p.lit}
+		p.next()
+		return &ast.UnaryExpr{OpPos: opPos, Op: token.SUB, X: x}
+	}
+
+	if !p.isLiteral(p.tok) {
+		p.errorExpected(msg)
+		return nil
+	}
+	lit := &ast.BasicLit{ValuePos: p.pos, Kind: p.tok, Value: p.lit}
+	p.next()
+	return lit
+}
+
 func (p *Parser) isLiteral(tok token.Token) bool {
 	switch tok {
 	case token.INT, token.FLOAT, token.STRING, token.CHAR, token.IDENT, token.TRUE, token.FALSE:
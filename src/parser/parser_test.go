@@ -0,0 +1,241 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/kkumar-gcc/enumgen/src/ast"
+	"github.com/kkumar-gcc/enumgen/src/lexer"
+	"github.com/kkumar-gcc/enumgen/src/parser"
+)
+
+// parse parses src and returns its enums, failing the test on syntax errors.
+func parse(t *testing.T, src string) []*ast.EnumDefinition {
+	t.Helper()
+
+	p := parser.New(lexer.New("test.edl", []byte(src), lexer.CommentMode))
+	file := p.Parse()
+	if errs := p.Errors(); len(errs) > 0 {
+		t.Fatalf("unexpected parse errors: %v", errs)
+	}
+
+	var enums []*ast.EnumDefinition
+	for _, decl := range file.Declarations {
+		if enum, ok := decl.(*ast.EnumDefinition); ok {
+			enums = append(enums, enum)
+		}
+	}
+	return enums
+}
+
+func TestParseFlagsModifier(t *testing.T) {
+	enums := parse(t, `
+enum Perm [uint8] flags:
+    READ,
+    flags;
+
+enum flags:
+    flags;
+
+enum Mode flags:
+    A,
+    B;
+`)
+	if len(enums) != 3 {
+		t.Fatalf("expected 3 enums, got %d", len(enums))
+	}
+
+	tests := []struct {
+		name   string
+		member string
+		flags  bool
+	}{
+		{name: "Perm", member: "flags", flags: true},
+		{name: "flags", member: "flags", flags: false},
+		{name: "Mode", member: "B", flags: true},
+	}
+	for i, tt := range tests {
+		enum := enums[i]
+		if enum.Name.Name != tt.name {
+			t.Errorf("enum %d: expected name %s, got %s", i, tt.name, enum.Name.Name)
+		}
+		if enum.IsFlags() != tt.flags {
+			t.Errorf("enum %s: expected IsFlags() = %v", tt.name, tt.flags)
+		}
+		if last := enum.Members[len(enum.Members)-1]; last.Name.Name != tt.member {
+			t.Errorf("enum %s: expected last member %s, got %s", tt.name, tt.member, last.Name.Name)
+		}
+	}
+}
+
+func TestParseMemberComments(t *testing.T) {
+	enums := parse(t, `
+enum Color:
+    // RED is the primary colour.
+    RED, // warm
+    // GREEN has no trailing comment.
+    GREEN,
+    BLUE; // cool
+`)
+	if len(enums) != 1 || len(enums[0].Members) != 3 {
+		t.Fatalf("expected 1 enum with 3 members, got %v", enums)
+	}
+
+	tests := []struct {
+		doc     string
+		comment string
+		text    string
+	}{
+		{doc: "RED is the primary colour.", comment: "warm", text: "RED is the primary colour."},
+		{doc: "GREEN has no trailing comment.", comment: "", text: "GREEN has no trailing comment."},
+		{doc: "", comment: "cool", text: "cool"},
+	}
+	for i, tt := range tests {
+		m := enums[0].Members[i]
+		if got := m.Doc.Text(); got != tt.doc {
+			t.Errorf("member %s: expected doc %q, got %q", m.Name.Name, tt.doc, got)
+		}
+		if got := m.Comment.Text(); got != tt.comment {
+			t.Errorf("member %s: expected comment %q, got %q", m.Name.Name, tt.comment, got)
+		}
+		if got := m.DocText(); got != tt.text {
+			t.Errorf("member %s: expected DocText %q, got %q", m.Name.Name, tt.text, got)
+		}
+	}
+}
+
+func TestParseOptionName(t *testing.T) {
+	p := parser.New(lexer.New("test.edl", []byte(`
+option default_type = int;
+
+enum option:
+    option,
+    other;
+
+enum Setting [option string unique, width int]:
+    COLUMNS = ("columns", 80);
+`), lexer.CommentMode))
+	file := p.Parse()
+	if errs := p.Errors(); len(errs) > 0 {
+		t.Fatalf("unexpected parse errors: %v", errs)
+	}
+	if len(file.Declarations) != 3 {
+		t.Fatalf("expected 3 declarations, got %d", len(file.Declarations))
+	}
+
+	opt, ok := file.Declarations[0].(*ast.OptionDecl)
+	if !ok || opt.Name.Name != "default_type" {
+		t.Fatalf("expected the default_type option first, got %#v", file.Declarations[0])
+	}
+	enum, ok := file.Declarations[1].(*ast.EnumDefinition)
+	if !ok || enum.Name.Name != "option" || enum.Members[0].Name.Name != "option" {
+		t.Errorf("expected an enum and member named option, got %#v", file.Declarations[1])
+	}
+	enum, ok = file.Declarations[2].(*ast.EnumDefinition)
+	if !ok || len(enum.TypeSpec.Fields) != 2 || enum.TypeSpec.Fields[0].Name.Name != "option" {
+		t.Errorf("expected a field named option, got %#v", file.Declarations[2])
+	}
+}
+
+func TestParseAliasName(t *testing.T) {
+	enums := parse(t, `
+enum Command [string]:
+    alias = "alias" alias "a", "al",
+    unalias,
+    run alias "r";
+
+enum Shortcut [alias string unique, key string]:
+    COPY = ("copy", "c");
+`)
+	if len(enums) != 2 || len(enums[0].Members) != 3 {
+		t.Fatalf("expected 2 enums, the first with 3 members, got %v", enums)
+	}
+
+	tests := []struct {
+		name    string
+		aliases []string
+	}{
+		{name: "alias", aliases: []string{`"a"`, `"al"`}},
+		{name: "unalias"},
+		{name: "run", aliases: []string{`"r"`}},
+	}
+	for i, tt := range tests {
+		m := enums[0].Members[i]
+		if m.Name.Name != tt.name {
+			t.Errorf("member %d: expected name %s, got %s", i, tt.name, m.Name.Name)
+		}
+		if len(m.Aliases) != len(tt.aliases) {
+			t.Errorf("member %s: expected aliases %v, got %d", tt.name, tt.aliases, len(m.Aliases))
+			continue
+		}
+		for j, alias := range m.Aliases {
+			if alias.Value != tt.aliases[j] {
+				t.Errorf("member %s: expected alias %s, got %s", tt.name, tt.aliases[j], alias.Value)
+			}
+		}
+	}
+	if field := enums[1].TypeSpec.Fields[0].Name.Name; field != "alias" {
+		t.Errorf("expected a field named alias, got %s", field)
+	}
+}
+
+func TestParseEnumAnnotations(t *testing.T) {
+	enums := parse(t, `// Enums of the shop.
+
+// Size of a shirt.
+enum Size [string]:
+    @label("Small")
+    SMALL = "s",
+    LARGE = "l";
+
+// Color of a shirt.
+@deprecated("use Shade")
+enum Color [string]:
+    @json("red")
+    RED = "red";
+
+// Shade of a shirt.
+@deprecated
+enum Shade [string]:
+    @label("Light")
+    @json("light")
+    LIGHT = "light";
+`)
+	if len(enums) != 3 {
+		t.Fatalf("expected 3 enums, got %d", len(enums))
+	}
+
+	tests := []struct {
+		name       string
+		doc        string
+		deprecated bool
+		member     []string
+	}{
+		{name: "Size", doc: "Size of a shirt.", member: []string{"label"}},
+		{name: "Color", doc: "Color of a shirt.", deprecated: true, member: []string{"json"}},
+		{name: "Shade", doc: "Shade of a shirt.", deprecated: true, member: []string{"label", "json"}},
+	}
+	for i, tt := range tests {
+		enum := enums[i]
+		if enum.Name.Name != tt.name {
+			t.Errorf("enum %d: expected name %s, got %s", i, tt.name, enum.Name.Name)
+			continue
+		}
+		if got := enum.DocText(); got != tt.doc {
+			t.Errorf("enum %s: expected doc %q, got %q", tt.name, tt.doc, got)
+		}
+		if got := enum.Annotation("deprecated") != nil; got != tt.deprecated {
+			t.Errorf("enum %s: expected deprecated = %v", tt.name, tt.deprecated)
+		}
+
+		annotations := enum.Members[0].Annotations
+		if len(annotations) != len(tt.member) {
+			t.Errorf("enum %s: expected member annotations %v, got %d", tt.name, tt.member, len(annotations))
+			continue
+		}
+		for j, a := range annotations {
+			if a.Name.Name != tt.member[j] {
+				t.Errorf("enum %s: expected member annotation @%s, got @%s", tt.name, tt.member[j], a.Name.Name)
+			}
+		}
+	}
+}
@@ -14,12 +14,15 @@ const (
 
 	ASSIGN
 	LBRACKET  // [
+	LPAREN    // (
 	COMMA     // ,
 	PERIOD    // .
 	RBRACKET  // ]
+	RPAREN    // )
 	SEMICOLON // ;
 	COLON     // :
 	SUB       // -
+	AT        // @
 
 	literal_beg
 	IDENT  // main
@@ -55,12 +58,15 @@ var tokens = [...]string{
 
 	LBRACKET:  "[",
 	RBRACKET:  "]",
+	LPAREN:    "(",
+	RPAREN:    ")",
 	COMMA:     ",",
 	COLON:     ":",
 	ASSIGN:    "=",
 	PERIOD:    ".",
 	SEMICOLON: ";",
 	SUB:       "-",
+	AT:        "@",
 
 	ENUM:  "enum",
 	KIND:  "kind",